@@ -73,6 +73,8 @@ const (
 	Operation_PLUS     Operation = 0
 	Operation_MINUS    Operation = 1
 	Operation_MULTIPLY Operation = 2
+	Operation_DIVIDE   Operation = 3
+	Operation_MODULO   Operation = 4
 )
 
 // Enum value maps for Operation.
@@ -81,11 +83,15 @@ var (
 		0: "PLUS",
 		1: "MINUS",
 		2: "MULTIPLY",
+		3: "DIVIDE",
+		4: "MODULO",
 	}
 	Operation_value = map[string]int32{
 		"PLUS":     0,
 		"MINUS":    1,
 		"MULTIPLY": 2,
+		"DIVIDE":   3,
+		"MODULO":   4,
 	}
 )
 
@@ -116,6 +122,315 @@ func (Operation) EnumDescriptor() ([]byte, []int) {
 	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{1}
 }
 
+type Rounding int32
+
+const (
+	Rounding_TRUNC     Rounding = 0
+	Rounding_FLOOR     Rounding = 1
+	Rounding_CEIL      Rounding = 2
+	Rounding_EUCLID    Rounding = 3
+	Rounding_HALF_UP   Rounding = 4
+	Rounding_HALF_EVEN Rounding = 5
+)
+
+// Enum value maps for Rounding.
+var (
+	Rounding_name = map[int32]string{
+		0: "TRUNC",
+		1: "FLOOR",
+		2: "CEIL",
+		3: "EUCLID",
+		4: "HALF_UP",
+		5: "HALF_EVEN",
+	}
+	Rounding_value = map[string]int32{
+		"TRUNC":     0,
+		"FLOOR":     1,
+		"CEIL":      2,
+		"EUCLID":    3,
+		"HALF_UP":   4,
+		"HALF_EVEN": 5,
+	}
+)
+
+func (x Rounding) Enum() *Rounding {
+	p := new(Rounding)
+	*p = x
+	return p
+}
+
+func (x Rounding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Rounding) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_indusrtial_calculator_proto_enumTypes[2].Descriptor()
+}
+
+func (Rounding) Type() protoreflect.EnumType {
+	return &file_api_indusrtial_calculator_proto_enumTypes[2]
+}
+
+func (x Rounding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Rounding.Descriptor instead.
+func (Rounding) EnumDescriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{2}
+}
+
+type OverflowMode int32
+
+const (
+	OverflowMode_OVERFLOW_ERROR    OverflowMode = 0
+	OverflowMode_OVERFLOW_WRAP     OverflowMode = 1
+	OverflowMode_OVERFLOW_SATURATE OverflowMode = 2
+)
+
+// Enum value maps for OverflowMode.
+var (
+	OverflowMode_name = map[int32]string{
+		0: "OVERFLOW_ERROR",
+		1: "OVERFLOW_WRAP",
+		2: "OVERFLOW_SATURATE",
+	}
+	OverflowMode_value = map[string]int32{
+		"OVERFLOW_ERROR":    0,
+		"OVERFLOW_WRAP":     1,
+		"OVERFLOW_SATURATE": 2,
+	}
+)
+
+func (x OverflowMode) Enum() *OverflowMode {
+	p := new(OverflowMode)
+	*p = x
+	return p
+}
+
+func (x OverflowMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OverflowMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_indusrtial_calculator_proto_enumTypes[3].Descriptor()
+}
+
+func (OverflowMode) Type() protoreflect.EnumType {
+	return &file_api_indusrtial_calculator_proto_enumTypes[3]
+}
+
+func (x OverflowMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OverflowMode.Descriptor instead.
+func (OverflowMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{3}
+}
+
+type NumericMode int32
+
+const (
+	NumericMode_NUMERIC_INT64   NumericMode = 0
+	NumericMode_NUMERIC_BIG     NumericMode = 1
+	NumericMode_NUMERIC_DECIMAL NumericMode = 2
+)
+
+// Enum value maps for NumericMode.
+var (
+	NumericMode_name = map[int32]string{
+		0: "NUMERIC_INT64",
+		1: "NUMERIC_BIG",
+		2: "NUMERIC_DECIMAL",
+	}
+	NumericMode_value = map[string]int32{
+		"NUMERIC_INT64":   0,
+		"NUMERIC_BIG":     1,
+		"NUMERIC_DECIMAL": 2,
+	}
+)
+
+func (x NumericMode) Enum() *NumericMode {
+	p := new(NumericMode)
+	*p = x
+	return p
+}
+
+func (x NumericMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NumericMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_indusrtial_calculator_proto_enumTypes[4].Descriptor()
+}
+
+func (NumericMode) Type() protoreflect.EnumType {
+	return &file_api_indusrtial_calculator_proto_enumTypes[4]
+}
+
+func (x NumericMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NumericMode.Descriptor instead.
+func (NumericMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{4}
+}
+
+type ExecutionMode int32
+
+const (
+	ExecutionMode_EXECUTION_MODE_DEFAULT    ExecutionMode = 0
+	ExecutionMode_EXECUTION_MODE_CONCURRENT ExecutionMode = 1
+	ExecutionMode_EXECUTION_MODE_SEQUENTIAL ExecutionMode = 2
+)
+
+// Enum value maps for ExecutionMode.
+var (
+	ExecutionMode_name = map[int32]string{
+		0: "EXECUTION_MODE_DEFAULT",
+		1: "EXECUTION_MODE_CONCURRENT",
+		2: "EXECUTION_MODE_SEQUENTIAL",
+	}
+	ExecutionMode_value = map[string]int32{
+		"EXECUTION_MODE_DEFAULT":    0,
+		"EXECUTION_MODE_CONCURRENT": 1,
+		"EXECUTION_MODE_SEQUENTIAL": 2,
+	}
+)
+
+func (x ExecutionMode) Enum() *ExecutionMode {
+	p := new(ExecutionMode)
+	*p = x
+	return p
+}
+
+func (x ExecutionMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExecutionMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_indusrtial_calculator_proto_enumTypes[5].Descriptor()
+}
+
+func (ExecutionMode) Type() protoreflect.EnumType {
+	return &file_api_indusrtial_calculator_proto_enumTypes[5]
+}
+
+func (x ExecutionMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExecutionMode.Descriptor instead.
+func (ExecutionMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{5}
+}
+
+type VariableStatus int32
+
+const (
+	VariableStatus_STATUS_OK        VariableStatus = 0
+	VariableStatus_STATUS_ERROR     VariableStatus = 1
+	VariableStatus_STATUS_TIMEOUT   VariableStatus = 2
+	VariableStatus_STATUS_CANCELLED VariableStatus = 3
+)
+
+// Enum value maps for VariableStatus.
+var (
+	VariableStatus_name = map[int32]string{
+		0: "STATUS_OK",
+		1: "STATUS_ERROR",
+		2: "STATUS_TIMEOUT",
+		3: "STATUS_CANCELLED",
+	}
+	VariableStatus_value = map[string]int32{
+		"STATUS_OK":        0,
+		"STATUS_ERROR":     1,
+		"STATUS_TIMEOUT":   2,
+		"STATUS_CANCELLED": 3,
+	}
+)
+
+func (x VariableStatus) Enum() *VariableStatus {
+	p := new(VariableStatus)
+	*p = x
+	return p
+}
+
+func (x VariableStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VariableStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_indusrtial_calculator_proto_enumTypes[6].Descriptor()
+}
+
+func (VariableStatus) Type() protoreflect.EnumType {
+	return &file_api_indusrtial_calculator_proto_enumTypes[6]
+}
+
+func (x VariableStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VariableStatus.Descriptor instead.
+func (VariableStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{6}
+}
+
+type JobStatus int32
+
+const (
+	JobStatus_JOB_RUNNING   JobStatus = 0
+	JobStatus_JOB_DONE      JobStatus = 1
+	JobStatus_JOB_FAILED    JobStatus = 2
+	JobStatus_JOB_CANCELLED JobStatus = 3
+)
+
+// Enum value maps for JobStatus.
+var (
+	JobStatus_name = map[int32]string{
+		0: "JOB_RUNNING",
+		1: "JOB_DONE",
+		2: "JOB_FAILED",
+		3: "JOB_CANCELLED",
+	}
+	JobStatus_value = map[string]int32{
+		"JOB_RUNNING":   0,
+		"JOB_DONE":      1,
+		"JOB_FAILED":    2,
+		"JOB_CANCELLED": 3,
+	}
+)
+
+func (x JobStatus) Enum() *JobStatus {
+	p := new(JobStatus)
+	*p = x
+	return p
+}
+
+func (x JobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_indusrtial_calculator_proto_enumTypes[7].Descriptor()
+}
+
+func (JobStatus) Type() protoreflect.EnumType {
+	return &file_api_indusrtial_calculator_proto_enumTypes[7]
+}
+
+func (x JobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobStatus.Descriptor instead.
+func (JobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{7}
+}
+
 type Command struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Type  CommandType            `protobuf:"varint,1,opt,name=type,proto3,enum=api.CommandType" json:"type,omitempty"`
@@ -125,12 +440,15 @@ type Command struct {
 	//
 	//	*Command_LeftInt
 	//	*Command_LeftStr
+	//	*Command_LeftDecimal
 	Left isCommand_Left `protobuf_oneof:"left"`
 	// Types that are valid to be assigned to Right:
 	//
 	//	*Command_RightInt
 	//	*Command_RightStr
+	//	*Command_RightDecimal
 	Right         isCommand_Right `protobuf_oneof:"right"`
+	Rounding      Rounding        `protobuf:"varint,8,opt,name=rounding,proto3,enum=api.Rounding" json:"rounding,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -211,6 +529,15 @@ func (x *Command) GetLeftStr() string {
 	return ""
 }
 
+func (x *Command) GetLeftDecimal() string {
+	if x != nil {
+		if x, ok := x.Left.(*Command_LeftDecimal); ok {
+			return x.LeftDecimal
+		}
+	}
+	return ""
+}
+
 func (x *Command) GetRight() isCommand_Right {
 	if x != nil {
 		return x.Right
@@ -236,6 +563,22 @@ func (x *Command) GetRightStr() string {
 	return ""
 }
 
+func (x *Command) GetRightDecimal() string {
+	if x != nil {
+		if x, ok := x.Right.(*Command_RightDecimal); ok {
+			return x.RightDecimal
+		}
+	}
+	return ""
+}
+
+func (x *Command) GetRounding() Rounding {
+	if x != nil {
+		return x.Rounding
+	}
+	return Rounding_TRUNC
+}
+
 type isCommand_Left interface {
 	isCommand_Left()
 }
@@ -248,10 +591,16 @@ type Command_LeftStr struct {
 	LeftStr string `protobuf:"bytes,5,opt,name=left_str,json=leftStr,proto3,oneof"`
 }
 
+type Command_LeftDecimal struct {
+	LeftDecimal string `protobuf:"bytes,9,opt,name=left_decimal,json=leftDecimal,proto3,oneof"`
+}
+
 func (*Command_LeftInt) isCommand_Left() {}
 
 func (*Command_LeftStr) isCommand_Left() {}
 
+func (*Command_LeftDecimal) isCommand_Left() {}
+
 type isCommand_Right interface {
 	isCommand_Right()
 }
@@ -264,13 +613,23 @@ type Command_RightStr struct {
 	RightStr string `protobuf:"bytes,7,opt,name=right_str,json=rightStr,proto3,oneof"`
 }
 
+type Command_RightDecimal struct {
+	RightDecimal string `protobuf:"bytes,10,opt,name=right_decimal,json=rightDecimal,proto3,oneof"`
+}
+
 func (*Command_RightInt) isCommand_Right() {}
 
 func (*Command_RightStr) isCommand_Right() {}
 
+func (*Command_RightDecimal) isCommand_Right() {}
+
 type ProcessRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Commands      []*Command             `protobuf:"bytes,1,rep,name=commands,proto3" json:"commands,omitempty"`
+	Overflow      OverflowMode           `protobuf:"varint,2,opt,name=overflow,proto3,enum=api.OverflowMode" json:"overflow,omitempty"`
+	Numeric       NumericMode            `protobuf:"varint,3,opt,name=numeric,proto3,enum=api.NumericMode" json:"numeric,omitempty"`
+	Scale         *int32                 `protobuf:"varint,4,opt,name=scale,proto3,oneof" json:"scale,omitempty"`
+	Mode          ExecutionMode          `protobuf:"varint,5,opt,name=mode,proto3,enum=api.ExecutionMode" json:"mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -312,28 +671,59 @@ func (x *ProcessRequest) GetCommands() []*Command {
 	return nil
 }
 
-type VariableResult struct {
+func (x *ProcessRequest) GetOverflow() OverflowMode {
+	if x != nil {
+		return x.Overflow
+	}
+	return OverflowMode_OVERFLOW_ERROR
+}
+
+func (x *ProcessRequest) GetNumeric() NumericMode {
+	if x != nil {
+		return x.Numeric
+	}
+	return NumericMode_NUMERIC_INT64
+}
+
+func (x *ProcessRequest) GetScale() int32 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
+	}
+	return 0
+}
+
+func (x *ProcessRequest) GetMode() ExecutionMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ExecutionMode_EXECUTION_MODE_DEFAULT
+}
+
+type ProcessExpressionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Var           string                 `protobuf:"bytes,1,opt,name=var,proto3" json:"var,omitempty"`
-	Value         int64                  `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Program       string                 `protobuf:"bytes,1,opt,name=program,proto3" json:"program,omitempty"`
+	Overflow      OverflowMode           `protobuf:"varint,2,opt,name=overflow,proto3,enum=api.OverflowMode" json:"overflow,omitempty"`
+	Numeric       NumericMode            `protobuf:"varint,3,opt,name=numeric,proto3,enum=api.NumericMode" json:"numeric,omitempty"`
+	Scale         *int32                 `protobuf:"varint,4,opt,name=scale,proto3,oneof" json:"scale,omitempty"`
+	Mode          ExecutionMode          `protobuf:"varint,5,opt,name=mode,proto3,enum=api.ExecutionMode" json:"mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VariableResult) Reset() {
-	*x = VariableResult{}
+func (x *ProcessExpressionRequest) Reset() {
+	*x = ProcessExpressionRequest{}
 	mi := &file_api_indusrtial_calculator_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VariableResult) String() string {
+func (x *ProcessExpressionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VariableResult) ProtoMessage() {}
+func (*ProcessExpressionRequest) ProtoMessage() {}
 
-func (x *VariableResult) ProtoReflect() protoreflect.Message {
+func (x *ProcessExpressionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_indusrtial_calculator_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -345,46 +735,73 @@ func (x *VariableResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VariableResult.ProtoReflect.Descriptor instead.
-func (*VariableResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProcessExpressionRequest.ProtoReflect.Descriptor instead.
+func (*ProcessExpressionRequest) Descriptor() ([]byte, []int) {
 	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *VariableResult) GetVar() string {
+func (x *ProcessExpressionRequest) GetProgram() string {
 	if x != nil {
-		return x.Var
+		return x.Program
 	}
 	return ""
 }
 
-func (x *VariableResult) GetValue() int64 {
+func (x *ProcessExpressionRequest) GetOverflow() OverflowMode {
 	if x != nil {
-		return x.Value
+		return x.Overflow
+	}
+	return OverflowMode_OVERFLOW_ERROR
+}
+
+func (x *ProcessExpressionRequest) GetNumeric() NumericMode {
+	if x != nil {
+		return x.Numeric
+	}
+	return NumericMode_NUMERIC_INT64
+}
+
+func (x *ProcessExpressionRequest) GetScale() int32 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
 	}
 	return 0
 }
 
-type ProcessResponse struct {
+func (x *ProcessExpressionRequest) GetMode() ExecutionMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ExecutionMode_EXECUTION_MODE_DEFAULT
+}
+
+type VariableResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Results       []*VariableResult      `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Var           string                 `protobuf:"bytes,1,opt,name=var,proto3" json:"var,omitempty"`
+	Value         int64                  `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	BigValue      string                 `protobuf:"bytes,4,opt,name=big_value,json=bigValue,proto3" json:"big_value,omitempty"`
+	DecimalValue  string                 `protobuf:"bytes,5,opt,name=decimal_value,json=decimalValue,proto3" json:"decimal_value,omitempty"`
+	Status        VariableStatus         `protobuf:"varint,6,opt,name=status,proto3,enum=api.VariableStatus" json:"status,omitempty"`
+	Index         int32                  `protobuf:"varint,7,opt,name=index,proto3" json:"index,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProcessResponse) Reset() {
-	*x = ProcessResponse{}
+func (x *VariableResult) Reset() {
+	*x = VariableResult{}
 	mi := &file_api_indusrtial_calculator_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProcessResponse) String() string {
+func (x *VariableResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProcessResponse) ProtoMessage() {}
+func (*VariableResult) ProtoMessage() {}
 
-func (x *ProcessResponse) ProtoReflect() protoreflect.Message {
+func (x *VariableResult) ProtoReflect() protoreflect.Message {
 	mi := &file_api_indusrtial_calculator_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -396,49 +813,1254 @@ func (x *ProcessResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProcessResponse.ProtoReflect.Descriptor instead.
-func (*ProcessResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VariableResult.ProtoReflect.Descriptor instead.
+func (*VariableResult) Descriptor() ([]byte, []int) {
 	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ProcessResponse) GetResults() []*VariableResult {
+func (x *VariableResult) GetVar() string {
 	if x != nil {
-		return x.Results
+		return x.Var
 	}
-	return nil
+	return ""
 }
 
-var File_api_indusrtial_calculator_proto protoreflect.FileDescriptor
+func (x *VariableResult) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
 
-const file_api_indusrtial_calculator_proto_rawDesc = "" +
-	"\n" +
-	"\x1fapi/indusrtial-calculator.proto\x12\x03api\"\xea\x01\n" +
-	"\aCommand\x12$\n" +
-	"\x04type\x18\x01 \x01(\x0e2\x10.api.CommandTypeR\x04type\x12\x10\n" +
-	"\x03var\x18\x02 \x01(\tR\x03var\x12\x1e\n" +
-	"\x02op\x18\x03 \x01(\x0e2\x0e.api.OperationR\x02op\x12\x1b\n" +
-	"\bleft_int\x18\x04 \x01(\x03H\x00R\aleftInt\x12\x1b\n" +
-	"\bleft_str\x18\x05 \x01(\tH\x00R\aleftStr\x12\x1d\n" +
-	"\tright_int\x18\x06 \x01(\x03H\x01R\brightInt\x12\x1d\n" +
-	"\tright_str\x18\a \x01(\tH\x01R\brightStrB\x06\n" +
-	"\x04leftB\a\n" +
-	"\x05right\":\n" +
+func (x *VariableResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *VariableResult) GetBigValue() string {
+	if x != nil {
+		return x.BigValue
+	}
+	return ""
+}
+
+func (x *VariableResult) GetDecimalValue() string {
+	if x != nil {
+		return x.DecimalValue
+	}
+	return ""
+}
+
+func (x *VariableResult) GetStatus() VariableStatus {
+	if x != nil {
+		return x.Status
+	}
+	return VariableStatus_STATUS_OK
+}
+
+func (x *VariableResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type ProcessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*VariableResult      `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessResponse) Reset() {
+	*x = ProcessResponse{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessResponse) ProtoMessage() {}
+
+func (x *ProcessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessResponse.ProtoReflect.Descriptor instead.
+func (*ProcessResponse) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProcessResponse) GetResults() []*VariableResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type Derivation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *VariableResult        `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Literal       bool                   `protobuf:"varint,2,opt,name=literal,proto3" json:"literal,omitempty"`
+	CommandIndex  int32                  `protobuf:"varint,3,opt,name=command_index,json=commandIndex,proto3" json:"command_index,omitempty"`
+	Op            Operation              `protobuf:"varint,4,opt,name=op,proto3,enum=api.Operation" json:"op,omitempty"`
+	Rounding      Rounding               `protobuf:"varint,5,opt,name=rounding,proto3,enum=api.Rounding" json:"rounding,omitempty"`
+	Shared        bool                   `protobuf:"varint,6,opt,name=shared,proto3" json:"shared,omitempty"`
+	Left          *Derivation            `protobuf:"bytes,7,opt,name=left,proto3" json:"left,omitempty"`
+	Right         *Derivation            `protobuf:"bytes,8,opt,name=right,proto3" json:"right,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Derivation) Reset() {
+	*x = Derivation{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Derivation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Derivation) ProtoMessage() {}
+
+func (x *Derivation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Derivation.ProtoReflect.Descriptor instead.
+func (*Derivation) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Derivation) GetResult() *VariableResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Derivation) GetLiteral() bool {
+	if x != nil {
+		return x.Literal
+	}
+	return false
+}
+
+func (x *Derivation) GetCommandIndex() int32 {
+	if x != nil {
+		return x.CommandIndex
+	}
+	return 0
+}
+
+func (x *Derivation) GetOp() Operation {
+	if x != nil {
+		return x.Op
+	}
+	return Operation_PLUS
+}
+
+func (x *Derivation) GetRounding() Rounding {
+	if x != nil {
+		return x.Rounding
+	}
+	return Rounding_TRUNC
+}
+
+func (x *Derivation) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *Derivation) GetLeft() *Derivation {
+	if x != nil {
+		return x.Left
+	}
+	return nil
+}
+
+func (x *Derivation) GetRight() *Derivation {
+	if x != nil {
+		return x.Right
+	}
+	return nil
+}
+
+type ExplainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Derivations   []*Derivation          `protobuf:"bytes,1,rep,name=derivations,proto3" json:"derivations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainResponse) Reset() {
+	*x = ExplainResponse{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainResponse) ProtoMessage() {}
+
+func (x *ExplainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainResponse.ProtoReflect.Descriptor instead.
+func (*ExplainResponse) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExplainResponse) GetDerivations() []*Derivation {
+	if x != nil {
+		return x.Derivations
+	}
+	return nil
+}
+
+type PlannedCommand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommandIndex  int32                  `protobuf:"varint,1,opt,name=command_index,json=commandIndex,proto3" json:"command_index,omitempty"`
+	Var           string                 `protobuf:"bytes,2,opt,name=var,proto3" json:"var,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlannedCommand) Reset() {
+	*x = PlannedCommand{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlannedCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlannedCommand) ProtoMessage() {}
+
+func (x *PlannedCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlannedCommand.ProtoReflect.Descriptor instead.
+func (*PlannedCommand) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PlannedCommand) GetCommandIndex() int32 {
+	if x != nil {
+		return x.CommandIndex
+	}
+	return 0
+}
+
+func (x *PlannedCommand) GetVar() string {
+	if x != nil {
+		return x.Var
+	}
+	return ""
+}
+
+type PlanLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commands      []*PlannedCommand      `protobuf:"bytes,1,rep,name=commands,proto3" json:"commands,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanLevel) Reset() {
+	*x = PlanLevel{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanLevel) ProtoMessage() {}
+
+func (x *PlanLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanLevel.ProtoReflect.Descriptor instead.
+func (*PlanLevel) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PlanLevel) GetCommands() []*PlannedCommand {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+type PlanResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Required           []*PlannedCommand      `protobuf:"bytes,1,rep,name=required,proto3" json:"required,omitempty"`
+	Levels             []*PlanLevel           `protobuf:"bytes,2,rep,name=levels,proto3" json:"levels,omitempty"`
+	CriticalPathLength int32                  `protobuf:"varint,3,opt,name=critical_path_length,json=criticalPathLength,proto3" json:"critical_path_length,omitempty"`
+	CriticalPath       []*PlannedCommand      `protobuf:"bytes,4,rep,name=critical_path,json=criticalPath,proto3" json:"critical_path,omitempty"`
+	Dead               []*PlannedCommand      `protobuf:"bytes,5,rep,name=dead,proto3" json:"dead,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PlanResponse) Reset() {
+	*x = PlanResponse{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanResponse) ProtoMessage() {}
+
+func (x *PlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanResponse.ProtoReflect.Descriptor instead.
+func (*PlanResponse) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PlanResponse) GetRequired() []*PlannedCommand {
+	if x != nil {
+		return x.Required
+	}
+	return nil
+}
+
+func (x *PlanResponse) GetLevels() []*PlanLevel {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+func (x *PlanResponse) GetCriticalPathLength() int32 {
+	if x != nil {
+		return x.CriticalPathLength
+	}
+	return 0
+}
+
+func (x *PlanResponse) GetCriticalPath() []*PlannedCommand {
+	if x != nil {
+		return x.CriticalPath
+	}
+	return nil
+}
+
+func (x *PlanResponse) GetDead() []*PlannedCommand {
+	if x != nil {
+		return x.Dead
+	}
+	return nil
+}
+
+type StreamSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Ok            int32                  `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Errors        int32                  `protobuf:"varint,3,opt,name=errors,proto3" json:"errors,omitempty"`
+	Timeouts      int32                  `protobuf:"varint,4,opt,name=timeouts,proto3" json:"timeouts,omitempty"`
+	Cancelled     int32                  `protobuf:"varint,5,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	ElapsedMs     int64                  `protobuf:"varint,6,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamSummary) Reset() {
+	*x = StreamSummary{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSummary) ProtoMessage() {}
+
+func (x *StreamSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSummary.ProtoReflect.Descriptor instead.
+func (*StreamSummary) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StreamSummary) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *StreamSummary) GetOk() int32 {
+	if x != nil {
+		return x.Ok
+	}
+	return 0
+}
+
+func (x *StreamSummary) GetErrors() int32 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *StreamSummary) GetTimeouts() int32 {
+	if x != nil {
+		return x.Timeouts
+	}
+	return 0
+}
+
+func (x *StreamSummary) GetCancelled() int32 {
+	if x != nil {
+		return x.Cancelled
+	}
+	return 0
+}
+
+func (x *StreamSummary) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+type ProcessStreamResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*ProcessStreamResponse_Result
+	//	*ProcessStreamResponse_Summary
+	Event         isProcessStreamResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessStreamResponse) Reset() {
+	*x = ProcessStreamResponse{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessStreamResponse) ProtoMessage() {}
+
+func (x *ProcessStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessStreamResponse.ProtoReflect.Descriptor instead.
+func (*ProcessStreamResponse) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ProcessStreamResponse) GetEvent() isProcessStreamResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ProcessStreamResponse) GetResult() *VariableResult {
+	if x != nil {
+		if x, ok := x.Event.(*ProcessStreamResponse_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *ProcessStreamResponse) GetSummary() *StreamSummary {
+	if x != nil {
+		if x, ok := x.Event.(*ProcessStreamResponse_Summary); ok {
+			return x.Summary
+		}
+	}
+	return nil
+}
+
+type isProcessStreamResponse_Event interface {
+	isProcessStreamResponse_Event()
+}
+
+type ProcessStreamResponse_Result struct {
+	Result *VariableResult `protobuf:"bytes,1,opt,name=result,proto3,oneof"`
+}
+
+type ProcessStreamResponse_Summary struct {
+	Summary *StreamSummary `protobuf:"bytes,2,opt,name=summary,proto3,oneof"`
+}
+
+func (*ProcessStreamResponse_Result) isProcessStreamResponse_Event() {}
+
+func (*ProcessStreamResponse_Summary) isProcessStreamResponse_Event() {}
+
+type SessionStart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Overflow      OverflowMode           `protobuf:"varint,1,opt,name=overflow,proto3,enum=api.OverflowMode" json:"overflow,omitempty"`
+	Numeric       NumericMode            `protobuf:"varint,2,opt,name=numeric,proto3,enum=api.NumericMode" json:"numeric,omitempty"`
+	Scale         *int32                 `protobuf:"varint,3,opt,name=scale,proto3,oneof" json:"scale,omitempty"`
+	Mode          ExecutionMode          `protobuf:"varint,4,opt,name=mode,proto3,enum=api.ExecutionMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionStart) Reset() {
+	*x = SessionStart{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionStart) ProtoMessage() {}
+
+func (x *SessionStart) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionStart.ProtoReflect.Descriptor instead.
+func (*SessionStart) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SessionStart) GetOverflow() OverflowMode {
+	if x != nil {
+		return x.Overflow
+	}
+	return OverflowMode_OVERFLOW_ERROR
+}
+
+func (x *SessionStart) GetNumeric() NumericMode {
+	if x != nil {
+		return x.Numeric
+	}
+	return NumericMode_NUMERIC_INT64
+}
+
+func (x *SessionStart) GetScale() int32 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
+	}
+	return 0
+}
+
+func (x *SessionStart) GetMode() ExecutionMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ExecutionMode_EXECUTION_MODE_DEFAULT
+}
+
+type EndOfInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndOfInput) Reset() {
+	*x = EndOfInput{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndOfInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndOfInput) ProtoMessage() {}
+
+func (x *EndOfInput) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndOfInput.ProtoReflect.Descriptor instead.
+func (*EndOfInput) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{13}
+}
+
+type SessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*SessionRequest_Start
+	//	*SessionRequest_Command
+	//	*SessionRequest_End
+	Event         isSessionRequest_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRequest) Reset() {
+	*x = SessionRequest{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRequest) ProtoMessage() {}
+
+func (x *SessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRequest.ProtoReflect.Descriptor instead.
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SessionRequest) GetEvent() isSessionRequest_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetStart() *SessionStart {
+	if x != nil {
+		if x, ok := x.Event.(*SessionRequest_Start); ok {
+			return x.Start
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetCommand() *Command {
+	if x != nil {
+		if x, ok := x.Event.(*SessionRequest_Command); ok {
+			return x.Command
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetEnd() *EndOfInput {
+	if x != nil {
+		if x, ok := x.Event.(*SessionRequest_End); ok {
+			return x.End
+		}
+	}
+	return nil
+}
+
+type isSessionRequest_Event interface {
+	isSessionRequest_Event()
+}
+
+type SessionRequest_Start struct {
+	Start *SessionStart `protobuf:"bytes,1,opt,name=start,proto3,oneof"`
+}
+
+type SessionRequest_Command struct {
+	Command *Command `protobuf:"bytes,2,opt,name=command,proto3,oneof"`
+}
+
+type SessionRequest_End struct {
+	End *EndOfInput `protobuf:"bytes,3,opt,name=end,proto3,oneof"`
+}
+
+func (*SessionRequest_Start) isSessionRequest_Event() {}
+
+func (*SessionRequest_Command) isSessionRequest_Event() {}
+
+func (*SessionRequest_End) isSessionRequest_Event() {}
+
+type CommandRejected struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandRejected) Reset() {
+	*x = CommandRejected{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandRejected) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandRejected) ProtoMessage() {}
+
+func (x *CommandRejected) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandRejected.ProtoReflect.Descriptor instead.
+func (*CommandRejected) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CommandRejected) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *CommandRejected) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CommandRejected) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SessionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*SessionResponse_Result
+	//	*SessionResponse_Rejected
+	//	*SessionResponse_Summary
+	Event         isSessionResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionResponse) Reset() {
+	*x = SessionResponse{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionResponse) ProtoMessage() {}
+
+func (x *SessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionResponse.ProtoReflect.Descriptor instead.
+func (*SessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SessionResponse) GetEvent() isSessionResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *SessionResponse) GetResult() *VariableResult {
+	if x != nil {
+		if x, ok := x.Event.(*SessionResponse_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *SessionResponse) GetRejected() *CommandRejected {
+	if x != nil {
+		if x, ok := x.Event.(*SessionResponse_Rejected); ok {
+			return x.Rejected
+		}
+	}
+	return nil
+}
+
+func (x *SessionResponse) GetSummary() *StreamSummary {
+	if x != nil {
+		if x, ok := x.Event.(*SessionResponse_Summary); ok {
+			return x.Summary
+		}
+	}
+	return nil
+}
+
+type isSessionResponse_Event interface {
+	isSessionResponse_Event()
+}
+
+type SessionResponse_Result struct {
+	Result *VariableResult `protobuf:"bytes,1,opt,name=result,proto3,oneof"`
+}
+
+type SessionResponse_Rejected struct {
+	Rejected *CommandRejected `protobuf:"bytes,2,opt,name=rejected,proto3,oneof"`
+}
+
+type SessionResponse_Summary struct {
+	Summary *StreamSummary `protobuf:"bytes,3,opt,name=summary,proto3,oneof"`
+}
+
+func (*SessionResponse_Result) isSessionResponse_Event() {}
+
+func (*SessionResponse_Rejected) isSessionResponse_Event() {}
+
+func (*SessionResponse_Summary) isSessionResponse_Event() {}
+
+type JobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobRequest) Reset() {
+	*x = JobRequest{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobRequest) ProtoMessage() {}
+
+func (x *JobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobRequest.ProtoReflect.Descriptor instead.
+func (*JobRequest) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *JobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type JobResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status           JobStatus              `protobuf:"varint,2,opt,name=status,proto3,enum=api.JobStatus" json:"status,omitempty"`
+	Resolved         int32                  `protobuf:"varint,3,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	Required         int32                  `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	Results          []*VariableResult      `protobuf:"bytes,5,rep,name=results,proto3" json:"results,omitempty"`
+	Error            string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAtUnixMs  int64                  `protobuf:"varint,7,opt,name=created_at_unix_ms,json=createdAtUnixMs,proto3" json:"created_at_unix_ms,omitempty"`
+	FinishedAtUnixMs int64                  `protobuf:"varint,8,opt,name=finished_at_unix_ms,json=finishedAtUnixMs,proto3" json:"finished_at_unix_ms,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *JobResponse) Reset() {
+	*x = JobResponse{}
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobResponse) ProtoMessage() {}
+
+func (x *JobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_indusrtial_calculator_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobResponse.ProtoReflect.Descriptor instead.
+func (*JobResponse) Descriptor() ([]byte, []int) {
+	return file_api_indusrtial_calculator_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *JobResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JobResponse) GetStatus() JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return JobStatus_JOB_RUNNING
+}
+
+func (x *JobResponse) GetResolved() int32 {
+	if x != nil {
+		return x.Resolved
+	}
+	return 0
+}
+
+func (x *JobResponse) GetRequired() int32 {
+	if x != nil {
+		return x.Required
+	}
+	return 0
+}
+
+func (x *JobResponse) GetResults() []*VariableResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *JobResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *JobResponse) GetCreatedAtUnixMs() int64 {
+	if x != nil {
+		return x.CreatedAtUnixMs
+	}
+	return 0
+}
+
+func (x *JobResponse) GetFinishedAtUnixMs() int64 {
+	if x != nil {
+		return x.FinishedAtUnixMs
+	}
+	return 0
+}
+
+var File_api_indusrtial_calculator_proto protoreflect.FileDescriptor
+
+const file_api_indusrtial_calculator_proto_rawDesc = "" +
+	"\n" +
+	"\x1fapi/indusrtial-calculator.proto\x12\x03api\"\xe1\x02\n" +
+	"\aCommand\x12$\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x10.api.CommandTypeR\x04type\x12\x10\n" +
+	"\x03var\x18\x02 \x01(\tR\x03var\x12\x1e\n" +
+	"\x02op\x18\x03 \x01(\x0e2\x0e.api.OperationR\x02op\x12\x1b\n" +
+	"\bleft_int\x18\x04 \x01(\x03H\x00R\aleftInt\x12\x1b\n" +
+	"\bleft_str\x18\x05 \x01(\tH\x00R\aleftStr\x12#\n" +
+	"\fleft_decimal\x18\t \x01(\tH\x00R\vleftDecimal\x12\x1d\n" +
+	"\tright_int\x18\x06 \x01(\x03H\x01R\brightInt\x12\x1d\n" +
+	"\tright_str\x18\a \x01(\tH\x01R\brightStr\x12%\n" +
+	"\rright_decimal\x18\n" +
+	" \x01(\tH\x01R\frightDecimal\x12)\n" +
+	"\brounding\x18\b \x01(\x0e2\r.api.RoundingR\broundingB\x06\n" +
+	"\x04leftB\a\n" +
+	"\x05right\"\xe2\x01\n" +
 	"\x0eProcessRequest\x12(\n" +
-	"\bcommands\x18\x01 \x03(\v2\f.api.CommandR\bcommands\"8\n" +
+	"\bcommands\x18\x01 \x03(\v2\f.api.CommandR\bcommands\x12-\n" +
+	"\boverflow\x18\x02 \x01(\x0e2\x11.api.OverflowModeR\boverflow\x12*\n" +
+	"\anumeric\x18\x03 \x01(\x0e2\x10.api.NumericModeR\anumeric\x12\x19\n" +
+	"\x05scale\x18\x04 \x01(\x05H\x00R\x05scale\x88\x01\x01\x12&\n" +
+	"\x04mode\x18\x05 \x01(\x0e2\x12.api.ExecutionModeR\x04modeB\b\n" +
+	"\x06_scale\"\xdc\x01\n" +
+	"\x18ProcessExpressionRequest\x12\x18\n" +
+	"\aprogram\x18\x01 \x01(\tR\aprogram\x12-\n" +
+	"\boverflow\x18\x02 \x01(\x0e2\x11.api.OverflowModeR\boverflow\x12*\n" +
+	"\anumeric\x18\x03 \x01(\x0e2\x10.api.NumericModeR\anumeric\x12\x19\n" +
+	"\x05scale\x18\x04 \x01(\x05H\x00R\x05scale\x88\x01\x01\x12&\n" +
+	"\x04mode\x18\x05 \x01(\x0e2\x12.api.ExecutionModeR\x04modeB\b\n" +
+	"\x06_scale\"\xd3\x01\n" +
 	"\x0eVariableResult\x12\x10\n" +
 	"\x03var\x18\x01 \x01(\tR\x03var\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value\"@\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1b\n" +
+	"\tbig_value\x18\x04 \x01(\tR\bbigValue\x12#\n" +
+	"\rdecimal_value\x18\x05 \x01(\tR\fdecimalValue\x12+\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x13.api.VariableStatusR\x06status\x12\x14\n" +
+	"\x05index\x18\a \x01(\x05R\x05index\"@\n" +
 	"\x0fProcessResponse\x12-\n" +
-	"\aresults\x18\x01 \x03(\v2\x13.api.VariableResultR\aresults*\"\n" +
+	"\aresults\x18\x01 \x03(\v2\x13.api.VariableResultR\aresults\"\xa7\x02\n" +
+	"\n" +
+	"Derivation\x12+\n" +
+	"\x06result\x18\x01 \x01(\v2\x13.api.VariableResultR\x06result\x12\x18\n" +
+	"\aliteral\x18\x02 \x01(\bR\aliteral\x12#\n" +
+	"\rcommand_index\x18\x03 \x01(\x05R\fcommandIndex\x12\x1e\n" +
+	"\x02op\x18\x04 \x01(\x0e2\x0e.api.OperationR\x02op\x12)\n" +
+	"\brounding\x18\x05 \x01(\x0e2\r.api.RoundingR\brounding\x12\x16\n" +
+	"\x06shared\x18\x06 \x01(\bR\x06shared\x12#\n" +
+	"\x04left\x18\a \x01(\v2\x0f.api.DerivationR\x04left\x12%\n" +
+	"\x05right\x18\b \x01(\v2\x0f.api.DerivationR\x05right\"D\n" +
+	"\x0fExplainResponse\x121\n" +
+	"\vderivations\x18\x01 \x03(\v2\x0f.api.DerivationR\vderivations\"G\n" +
+	"\x0ePlannedCommand\x12#\n" +
+	"\rcommand_index\x18\x01 \x01(\x05R\fcommandIndex\x12\x10\n" +
+	"\x03var\x18\x02 \x01(\tR\x03var\"<\n" +
+	"\tPlanLevel\x12/\n" +
+	"\bcommands\x18\x01 \x03(\v2\x13.api.PlannedCommandR\bcommands\"\xfc\x01\n" +
+	"\fPlanResponse\x12/\n" +
+	"\brequired\x18\x01 \x03(\v2\x13.api.PlannedCommandR\brequired\x12&\n" +
+	"\x06levels\x18\x02 \x03(\v2\x0e.api.PlanLevelR\x06levels\x120\n" +
+	"\x14critical_path_length\x18\x03 \x01(\x05R\x12criticalPathLength\x128\n" +
+	"\rcritical_path\x18\x04 \x03(\v2\x13.api.PlannedCommandR\fcriticalPath\x12'\n" +
+	"\x04dead\x18\x05 \x03(\v2\x13.api.PlannedCommandR\x04dead\"\xa6\x01\n" +
+	"\rStreamSummary\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\x05R\x02ok\x12\x16\n" +
+	"\x06errors\x18\x03 \x01(\x05R\x06errors\x12\x1a\n" +
+	"\btimeouts\x18\x04 \x01(\x05R\btimeouts\x12\x1c\n" +
+	"\tcancelled\x18\x05 \x01(\x05R\tcancelled\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x06 \x01(\x03R\telapsedMs\"\x7f\n" +
+	"\x15ProcessStreamResponse\x12-\n" +
+	"\x06result\x18\x01 \x01(\v2\x13.api.VariableResultH\x00R\x06result\x12.\n" +
+	"\asummary\x18\x02 \x01(\v2\x12.api.StreamSummaryH\x00R\asummaryB\a\n" +
+	"\x05event\"\xb6\x01\n" +
+	"\fSessionStart\x12-\n" +
+	"\boverflow\x18\x01 \x01(\x0e2\x11.api.OverflowModeR\boverflow\x12*\n" +
+	"\anumeric\x18\x02 \x01(\x0e2\x10.api.NumericModeR\anumeric\x12\x19\n" +
+	"\x05scale\x18\x03 \x01(\x05H\x00R\x05scale\x88\x01\x01\x12&\n" +
+	"\x04mode\x18\x04 \x01(\x0e2\x12.api.ExecutionModeR\x04modeB\b\n" +
+	"\x06_scale\"\f\n" +
+	"\n" +
+	"EndOfInput\"\x93\x01\n" +
+	"\x0eSessionRequest\x12)\n" +
+	"\x05start\x18\x01 \x01(\v2\x11.api.SessionStartH\x00R\x05start\x12(\n" +
+	"\acommand\x18\x02 \x01(\v2\f.api.CommandH\x00R\acommand\x12#\n" +
+	"\x03end\x18\x03 \x01(\v2\x0f.api.EndOfInputH\x00R\x03endB\a\n" +
+	"\x05event\"U\n" +
+	"\x0fCommandRejected\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xad\x01\n" +
+	"\x0fSessionResponse\x12-\n" +
+	"\x06result\x18\x01 \x01(\v2\x13.api.VariableResultH\x00R\x06result\x122\n" +
+	"\brejected\x18\x02 \x01(\v2\x14.api.CommandRejectedH\x00R\brejected\x12.\n" +
+	"\asummary\x18\x03 \x01(\v2\x12.api.StreamSummaryH\x00R\asummaryB\a\n" +
+	"\x05event\"\x1c\n" +
+	"\n" +
+	"JobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9e\x02\n" +
+	"\vJobResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x0e.api.JobStatusR\x06status\x12\x1a\n" +
+	"\bresolved\x18\x03 \x01(\x05R\bresolved\x12\x1a\n" +
+	"\brequired\x18\x04 \x01(\x05R\brequired\x12-\n" +
+	"\aresults\x18\x05 \x03(\v2\x13.api.VariableResultR\aresults\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12+\n" +
+	"\x12created_at_unix_ms\x18\a \x01(\x03R\x0fcreatedAtUnixMs\x12-\n" +
+	"\x13finished_at_unix_ms\x18\b \x01(\x03R\x10finishedAtUnixMs*\"\n" +
 	"\vCommandType\x12\t\n" +
 	"\x05PRINT\x10\x00\x12\b\n" +
-	"\x04CALC\x10\x01*.\n" +
+	"\x04CALC\x10\x01*F\n" +
 	"\tOperation\x12\b\n" +
 	"\x04PLUS\x10\x00\x12\t\n" +
 	"\x05MINUS\x10\x01\x12\f\n" +
-	"\bMULTIPLY\x10\x022L\n" +
+	"\bMULTIPLY\x10\x02\x12\n" +
+	"\n" +
+	"\x06DIVIDE\x10\x03\x12\n" +
+	"\n" +
+	"\x06MODULO\x10\x04*R\n" +
+	"\bRounding\x12\t\n" +
+	"\x05TRUNC\x10\x00\x12\t\n" +
+	"\x05FLOOR\x10\x01\x12\b\n" +
+	"\x04CEIL\x10\x02\x12\n" +
+	"\n" +
+	"\x06EUCLID\x10\x03\x12\v\n" +
+	"\aHALF_UP\x10\x04\x12\r\n" +
+	"\tHALF_EVEN\x10\x05*L\n" +
+	"\fOverflowMode\x12\x12\n" +
+	"\x0eOVERFLOW_ERROR\x10\x00\x12\x11\n" +
+	"\rOVERFLOW_WRAP\x10\x01\x12\x15\n" +
+	"\x11OVERFLOW_SATURATE\x10\x02*F\n" +
+	"\vNumericMode\x12\x11\n" +
+	"\rNUMERIC_INT64\x10\x00\x12\x0f\n" +
+	"\vNUMERIC_BIG\x10\x01\x12\x13\n" +
+	"\x0fNUMERIC_DECIMAL\x10\x02*i\n" +
+	"\rExecutionMode\x12\x1a\n" +
+	"\x16EXECUTION_MODE_DEFAULT\x10\x00\x12\x1d\n" +
+	"\x19EXECUTION_MODE_CONCURRENT\x10\x01\x12\x1d\n" +
+	"\x19EXECUTION_MODE_SEQUENTIAL\x10\x02*[\n" +
+	"\x0eVariableStatus\x12\r\n" +
+	"\tSTATUS_OK\x10\x00\x12\x10\n" +
+	"\fSTATUS_ERROR\x10\x01\x12\x12\n" +
+	"\x0eSTATUS_TIMEOUT\x10\x02\x12\x14\n" +
+	"\x10STATUS_CANCELLED\x10\x03*M\n" +
+	"\tJobStatus\x12\x0f\n" +
+	"\vJOB_RUNNING\x10\x00\x12\f\n" +
+	"\bJOB_DONE\x10\x01\x12\x0e\n" +
+	"\n" +
+	"JOB_FAILED\x10\x02\x12\x11\n" +
+	"\rJOB_CANCELLED\x10\x032\x8b\x04\n" +
 	"\x14IndustrialCalculator\x124\n" +
-	"\aProcess\x12\x13.api.ProcessRequest\x1a\x14.api.ProcessResponseB\x1aZ\x18industrial-calculator.v1b\x06proto3"
+	"\aProcess\x12\x13.api.ProcessRequest\x1a\x14.api.ProcessResponse\x12H\n" +
+	"\x11ProcessExpression\x12\x1d.api.ProcessExpressionRequest\x1a\x14.api.ProcessResponse\x124\n" +
+	"\aExplain\x12\x13.api.ProcessRequest\x1a\x14.api.ExplainResponse\x12.\n" +
+	"\x04Plan\x12\x13.api.ProcessRequest\x1a\x11.api.PlanResponse\x12B\n" +
+	"\rProcessStream\x12\x13.api.ProcessRequest\x1a\x1a.api.ProcessStreamResponse0\x01\x128\n" +
+	"\aSession\x12\x13.api.SessionRequest\x1a\x14.api.SessionResponse(\x010\x01\x122\n" +
+	"\tSubmitJob\x12\x13.api.ProcessRequest\x1a\x10.api.JobResponse\x12+\n" +
+	"\x06GetJob\x12\x0f.api.JobRequest\x1a\x10.api.JobResponse\x12.\n" +
+	"\tCancelJob\x12\x0f.api.JobRequest\x1a\x10.api.JobResponseB\x1aZ\x18industrial-calculator.v1b\x06proto3"
 
 var (
 	file_api_indusrtial_calculator_proto_rawDescOnce sync.Once
@@ -452,28 +2074,97 @@ func file_api_indusrtial_calculator_proto_rawDescGZIP() []byte {
 	return file_api_indusrtial_calculator_proto_rawDescData
 }
 
-var file_api_indusrtial_calculator_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_indusrtial_calculator_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_indusrtial_calculator_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_api_indusrtial_calculator_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_api_indusrtial_calculator_proto_goTypes = []any{
-	(CommandType)(0),        // 0: api.CommandType
-	(Operation)(0),          // 1: api.Operation
-	(*Command)(nil),         // 2: api.Command
-	(*ProcessRequest)(nil),  // 3: api.ProcessRequest
-	(*VariableResult)(nil),  // 4: api.VariableResult
-	(*ProcessResponse)(nil), // 5: api.ProcessResponse
+	(CommandType)(0),                 // 0: api.CommandType
+	(Operation)(0),                   // 1: api.Operation
+	(Rounding)(0),                    // 2: api.Rounding
+	(OverflowMode)(0),                // 3: api.OverflowMode
+	(NumericMode)(0),                 // 4: api.NumericMode
+	(ExecutionMode)(0),               // 5: api.ExecutionMode
+	(VariableStatus)(0),              // 6: api.VariableStatus
+	(JobStatus)(0),                   // 7: api.JobStatus
+	(*Command)(nil),                  // 8: api.Command
+	(*ProcessRequest)(nil),           // 9: api.ProcessRequest
+	(*ProcessExpressionRequest)(nil), // 10: api.ProcessExpressionRequest
+	(*VariableResult)(nil),           // 11: api.VariableResult
+	(*ProcessResponse)(nil),          // 12: api.ProcessResponse
+	(*Derivation)(nil),               // 13: api.Derivation
+	(*ExplainResponse)(nil),          // 14: api.ExplainResponse
+	(*PlannedCommand)(nil),           // 15: api.PlannedCommand
+	(*PlanLevel)(nil),                // 16: api.PlanLevel
+	(*PlanResponse)(nil),             // 17: api.PlanResponse
+	(*StreamSummary)(nil),            // 18: api.StreamSummary
+	(*ProcessStreamResponse)(nil),    // 19: api.ProcessStreamResponse
+	(*SessionStart)(nil),             // 20: api.SessionStart
+	(*EndOfInput)(nil),               // 21: api.EndOfInput
+	(*SessionRequest)(nil),           // 22: api.SessionRequest
+	(*CommandRejected)(nil),          // 23: api.CommandRejected
+	(*SessionResponse)(nil),          // 24: api.SessionResponse
+	(*JobRequest)(nil),               // 25: api.JobRequest
+	(*JobResponse)(nil),              // 26: api.JobResponse
 }
 var file_api_indusrtial_calculator_proto_depIdxs = []int32{
-	0, // 0: api.Command.type:type_name -> api.CommandType
-	1, // 1: api.Command.op:type_name -> api.Operation
-	2, // 2: api.ProcessRequest.commands:type_name -> api.Command
-	4, // 3: api.ProcessResponse.results:type_name -> api.VariableResult
-	3, // 4: api.IndustrialCalculator.Process:input_type -> api.ProcessRequest
-	5, // 5: api.IndustrialCalculator.Process:output_type -> api.ProcessResponse
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	0,  // 0: api.Command.type:type_name -> api.CommandType
+	1,  // 1: api.Command.op:type_name -> api.Operation
+	2,  // 2: api.Command.rounding:type_name -> api.Rounding
+	8,  // 3: api.ProcessRequest.commands:type_name -> api.Command
+	3,  // 4: api.ProcessRequest.overflow:type_name -> api.OverflowMode
+	4,  // 5: api.ProcessRequest.numeric:type_name -> api.NumericMode
+	5,  // 6: api.ProcessRequest.mode:type_name -> api.ExecutionMode
+	3,  // 7: api.ProcessExpressionRequest.overflow:type_name -> api.OverflowMode
+	4,  // 8: api.ProcessExpressionRequest.numeric:type_name -> api.NumericMode
+	5,  // 9: api.ProcessExpressionRequest.mode:type_name -> api.ExecutionMode
+	6,  // 10: api.VariableResult.status:type_name -> api.VariableStatus
+	11, // 11: api.ProcessResponse.results:type_name -> api.VariableResult
+	11, // 12: api.Derivation.result:type_name -> api.VariableResult
+	1,  // 13: api.Derivation.op:type_name -> api.Operation
+	2,  // 14: api.Derivation.rounding:type_name -> api.Rounding
+	13, // 15: api.Derivation.left:type_name -> api.Derivation
+	13, // 16: api.Derivation.right:type_name -> api.Derivation
+	13, // 17: api.ExplainResponse.derivations:type_name -> api.Derivation
+	15, // 18: api.PlanLevel.commands:type_name -> api.PlannedCommand
+	15, // 19: api.PlanResponse.required:type_name -> api.PlannedCommand
+	16, // 20: api.PlanResponse.levels:type_name -> api.PlanLevel
+	15, // 21: api.PlanResponse.critical_path:type_name -> api.PlannedCommand
+	15, // 22: api.PlanResponse.dead:type_name -> api.PlannedCommand
+	11, // 23: api.ProcessStreamResponse.result:type_name -> api.VariableResult
+	18, // 24: api.ProcessStreamResponse.summary:type_name -> api.StreamSummary
+	3,  // 25: api.SessionStart.overflow:type_name -> api.OverflowMode
+	4,  // 26: api.SessionStart.numeric:type_name -> api.NumericMode
+	5,  // 27: api.SessionStart.mode:type_name -> api.ExecutionMode
+	20, // 28: api.SessionRequest.start:type_name -> api.SessionStart
+	8,  // 29: api.SessionRequest.command:type_name -> api.Command
+	21, // 30: api.SessionRequest.end:type_name -> api.EndOfInput
+	11, // 31: api.SessionResponse.result:type_name -> api.VariableResult
+	23, // 32: api.SessionResponse.rejected:type_name -> api.CommandRejected
+	18, // 33: api.SessionResponse.summary:type_name -> api.StreamSummary
+	7,  // 34: api.JobResponse.status:type_name -> api.JobStatus
+	11, // 35: api.JobResponse.results:type_name -> api.VariableResult
+	9,  // 36: api.IndustrialCalculator.Process:input_type -> api.ProcessRequest
+	10, // 37: api.IndustrialCalculator.ProcessExpression:input_type -> api.ProcessExpressionRequest
+	9,  // 38: api.IndustrialCalculator.Explain:input_type -> api.ProcessRequest
+	9,  // 39: api.IndustrialCalculator.Plan:input_type -> api.ProcessRequest
+	9,  // 40: api.IndustrialCalculator.ProcessStream:input_type -> api.ProcessRequest
+	22, // 41: api.IndustrialCalculator.Session:input_type -> api.SessionRequest
+	9,  // 42: api.IndustrialCalculator.SubmitJob:input_type -> api.ProcessRequest
+	25, // 43: api.IndustrialCalculator.GetJob:input_type -> api.JobRequest
+	25, // 44: api.IndustrialCalculator.CancelJob:input_type -> api.JobRequest
+	12, // 45: api.IndustrialCalculator.Process:output_type -> api.ProcessResponse
+	12, // 46: api.IndustrialCalculator.ProcessExpression:output_type -> api.ProcessResponse
+	14, // 47: api.IndustrialCalculator.Explain:output_type -> api.ExplainResponse
+	17, // 48: api.IndustrialCalculator.Plan:output_type -> api.PlanResponse
+	19, // 49: api.IndustrialCalculator.ProcessStream:output_type -> api.ProcessStreamResponse
+	24, // 50: api.IndustrialCalculator.Session:output_type -> api.SessionResponse
+	26, // 51: api.IndustrialCalculator.SubmitJob:output_type -> api.JobResponse
+	26, // 52: api.IndustrialCalculator.GetJob:output_type -> api.JobResponse
+	26, // 53: api.IndustrialCalculator.CancelJob:output_type -> api.JobResponse
+	45, // [45:54] is the sub-list for method output_type
+	36, // [36:45] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_api_indusrtial_calculator_proto_init() }
@@ -484,16 +2175,35 @@ func file_api_indusrtial_calculator_proto_init() {
 	file_api_indusrtial_calculator_proto_msgTypes[0].OneofWrappers = []any{
 		(*Command_LeftInt)(nil),
 		(*Command_LeftStr)(nil),
+		(*Command_LeftDecimal)(nil),
 		(*Command_RightInt)(nil),
 		(*Command_RightStr)(nil),
+		(*Command_RightDecimal)(nil),
+	}
+	file_api_indusrtial_calculator_proto_msgTypes[1].OneofWrappers = []any{}
+	file_api_indusrtial_calculator_proto_msgTypes[2].OneofWrappers = []any{}
+	file_api_indusrtial_calculator_proto_msgTypes[11].OneofWrappers = []any{
+		(*ProcessStreamResponse_Result)(nil),
+		(*ProcessStreamResponse_Summary)(nil),
+	}
+	file_api_indusrtial_calculator_proto_msgTypes[12].OneofWrappers = []any{}
+	file_api_indusrtial_calculator_proto_msgTypes[14].OneofWrappers = []any{
+		(*SessionRequest_Start)(nil),
+		(*SessionRequest_Command)(nil),
+		(*SessionRequest_End)(nil),
+	}
+	file_api_indusrtial_calculator_proto_msgTypes[16].OneofWrappers = []any{
+		(*SessionResponse_Result)(nil),
+		(*SessionResponse_Rejected)(nil),
+		(*SessionResponse_Summary)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_indusrtial_calculator_proto_rawDesc), len(file_api_indusrtial_calculator_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   4,
+			NumEnums:      8,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
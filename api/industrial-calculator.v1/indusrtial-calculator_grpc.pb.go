@@ -19,7 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	IndustrialCalculator_Process_FullMethodName = "/api.IndustrialCalculator/Process"
+	IndustrialCalculator_Process_FullMethodName           = "/api.IndustrialCalculator/Process"
+	IndustrialCalculator_ProcessExpression_FullMethodName = "/api.IndustrialCalculator/ProcessExpression"
+	IndustrialCalculator_Explain_FullMethodName           = "/api.IndustrialCalculator/Explain"
+	IndustrialCalculator_Plan_FullMethodName              = "/api.IndustrialCalculator/Plan"
+	IndustrialCalculator_ProcessStream_FullMethodName     = "/api.IndustrialCalculator/ProcessStream"
+	IndustrialCalculator_Session_FullMethodName           = "/api.IndustrialCalculator/Session"
+	IndustrialCalculator_SubmitJob_FullMethodName         = "/api.IndustrialCalculator/SubmitJob"
+	IndustrialCalculator_GetJob_FullMethodName            = "/api.IndustrialCalculator/GetJob"
+	IndustrialCalculator_CancelJob_FullMethodName         = "/api.IndustrialCalculator/CancelJob"
 )
 
 // IndustrialCalculatorClient is the client API for IndustrialCalculator service.
@@ -27,6 +35,14 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type IndustrialCalculatorClient interface {
 	Process(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (*ProcessResponse, error)
+	ProcessExpression(ctx context.Context, in *ProcessExpressionRequest, opts ...grpc.CallOption) (*ProcessResponse, error)
+	Explain(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (*ExplainResponse, error)
+	Plan(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (*PlanResponse, error)
+	ProcessStream(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProcessStreamResponse], error)
+	Session(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SessionRequest, SessionResponse], error)
+	SubmitJob(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (*JobResponse, error)
+	GetJob(ctx context.Context, in *JobRequest, opts ...grpc.CallOption) (*JobResponse, error)
+	CancelJob(ctx context.Context, in *JobRequest, opts ...grpc.CallOption) (*JobResponse, error)
 }
 
 type industrialCalculatorClient struct {
@@ -47,11 +63,111 @@ func (c *industrialCalculatorClient) Process(ctx context.Context, in *ProcessReq
 	return out, nil
 }
 
+func (c *industrialCalculatorClient) ProcessExpression(ctx context.Context, in *ProcessExpressionRequest, opts ...grpc.CallOption) (*ProcessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProcessResponse)
+	err := c.cc.Invoke(ctx, IndustrialCalculator_ProcessExpression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *industrialCalculatorClient) Explain(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (*ExplainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainResponse)
+	err := c.cc.Invoke(ctx, IndustrialCalculator_Explain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *industrialCalculatorClient) Plan(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (*PlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanResponse)
+	err := c.cc.Invoke(ctx, IndustrialCalculator_Plan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *industrialCalculatorClient) ProcessStream(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProcessStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &IndustrialCalculator_ServiceDesc.Streams[0], IndustrialCalculator_ProcessStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ProcessRequest, ProcessStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IndustrialCalculator_ProcessStreamClient = grpc.ServerStreamingClient[ProcessStreamResponse]
+
+func (c *industrialCalculatorClient) Session(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SessionRequest, SessionResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &IndustrialCalculator_ServiceDesc.Streams[1], IndustrialCalculator_Session_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SessionRequest, SessionResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IndustrialCalculator_SessionClient = grpc.BidiStreamingClient[SessionRequest, SessionResponse]
+
+func (c *industrialCalculatorClient) SubmitJob(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (*JobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobResponse)
+	err := c.cc.Invoke(ctx, IndustrialCalculator_SubmitJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *industrialCalculatorClient) GetJob(ctx context.Context, in *JobRequest, opts ...grpc.CallOption) (*JobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobResponse)
+	err := c.cc.Invoke(ctx, IndustrialCalculator_GetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *industrialCalculatorClient) CancelJob(ctx context.Context, in *JobRequest, opts ...grpc.CallOption) (*JobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobResponse)
+	err := c.cc.Invoke(ctx, IndustrialCalculator_CancelJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IndustrialCalculatorServer is the server API for IndustrialCalculator service.
 // All implementations must embed UnimplementedIndustrialCalculatorServer
 // for forward compatibility.
 type IndustrialCalculatorServer interface {
 	Process(context.Context, *ProcessRequest) (*ProcessResponse, error)
+	ProcessExpression(context.Context, *ProcessExpressionRequest) (*ProcessResponse, error)
+	Explain(context.Context, *ProcessRequest) (*ExplainResponse, error)
+	Plan(context.Context, *ProcessRequest) (*PlanResponse, error)
+	ProcessStream(*ProcessRequest, grpc.ServerStreamingServer[ProcessStreamResponse]) error
+	Session(grpc.BidiStreamingServer[SessionRequest, SessionResponse]) error
+	SubmitJob(context.Context, *ProcessRequest) (*JobResponse, error)
+	GetJob(context.Context, *JobRequest) (*JobResponse, error)
+	CancelJob(context.Context, *JobRequest) (*JobResponse, error)
 	mustEmbedUnimplementedIndustrialCalculatorServer()
 }
 
@@ -65,6 +181,30 @@ type UnimplementedIndustrialCalculatorServer struct{}
 func (UnimplementedIndustrialCalculatorServer) Process(context.Context, *ProcessRequest) (*ProcessResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Process not implemented")
 }
+func (UnimplementedIndustrialCalculatorServer) ProcessExpression(context.Context, *ProcessExpressionRequest) (*ProcessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessExpression not implemented")
+}
+func (UnimplementedIndustrialCalculatorServer) Explain(context.Context, *ProcessRequest) (*ExplainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Explain not implemented")
+}
+func (UnimplementedIndustrialCalculatorServer) Plan(context.Context, *ProcessRequest) (*PlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Plan not implemented")
+}
+func (UnimplementedIndustrialCalculatorServer) ProcessStream(*ProcessRequest, grpc.ServerStreamingServer[ProcessStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ProcessStream not implemented")
+}
+func (UnimplementedIndustrialCalculatorServer) Session(grpc.BidiStreamingServer[SessionRequest, SessionResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Session not implemented")
+}
+func (UnimplementedIndustrialCalculatorServer) SubmitJob(context.Context, *ProcessRequest) (*JobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitJob not implemented")
+}
+func (UnimplementedIndustrialCalculatorServer) GetJob(context.Context, *JobRequest) (*JobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedIndustrialCalculatorServer) CancelJob(context.Context, *JobRequest) (*JobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
 func (UnimplementedIndustrialCalculatorServer) mustEmbedUnimplementedIndustrialCalculatorServer() {}
 func (UnimplementedIndustrialCalculatorServer) testEmbeddedByValue()                              {}
 
@@ -104,6 +244,132 @@ func _IndustrialCalculator_Process_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IndustrialCalculator_ProcessExpression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessExpressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndustrialCalculatorServer).ProcessExpression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndustrialCalculator_ProcessExpression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndustrialCalculatorServer).ProcessExpression(ctx, req.(*ProcessExpressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndustrialCalculator_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndustrialCalculatorServer).Explain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndustrialCalculator_Explain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndustrialCalculatorServer).Explain(ctx, req.(*ProcessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndustrialCalculator_Plan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndustrialCalculatorServer).Plan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndustrialCalculator_Plan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndustrialCalculatorServer).Plan(ctx, req.(*ProcessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndustrialCalculator_ProcessStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProcessRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IndustrialCalculatorServer).ProcessStream(m, &grpc.GenericServerStream[ProcessRequest, ProcessStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IndustrialCalculator_ProcessStreamServer = grpc.ServerStreamingServer[ProcessStreamResponse]
+
+func _IndustrialCalculator_Session_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(IndustrialCalculatorServer).Session(&grpc.GenericServerStream[SessionRequest, SessionResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IndustrialCalculator_SessionServer = grpc.BidiStreamingServer[SessionRequest, SessionResponse]
+
+func _IndustrialCalculator_SubmitJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndustrialCalculatorServer).SubmitJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndustrialCalculator_SubmitJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndustrialCalculatorServer).SubmitJob(ctx, req.(*ProcessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndustrialCalculator_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndustrialCalculatorServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndustrialCalculator_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndustrialCalculatorServer).GetJob(ctx, req.(*JobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndustrialCalculator_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndustrialCalculatorServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndustrialCalculator_CancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndustrialCalculatorServer).CancelJob(ctx, req.(*JobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // IndustrialCalculator_ServiceDesc is the grpc.ServiceDesc for IndustrialCalculator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +381,43 @@ var IndustrialCalculator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Process",
 			Handler:    _IndustrialCalculator_Process_Handler,
 		},
+		{
+			MethodName: "ProcessExpression",
+			Handler:    _IndustrialCalculator_ProcessExpression_Handler,
+		},
+		{
+			MethodName: "Explain",
+			Handler:    _IndustrialCalculator_Explain_Handler,
+		},
+		{
+			MethodName: "Plan",
+			Handler:    _IndustrialCalculator_Plan_Handler,
+		},
+		{
+			MethodName: "SubmitJob",
+			Handler:    _IndustrialCalculator_SubmitJob_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _IndustrialCalculator_GetJob_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _IndustrialCalculator_CancelJob_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ProcessStream",
+			Handler:       _IndustrialCalculator_ProcessStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Session",
+			Handler:       _IndustrialCalculator_Session_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/indusrtial-calculator.proto",
 }
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/expression_parser"
+	"industrial-calculator/internal/graph"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/script_parser"
+	"industrial-calculator/internal/usecase"
+	"io"
+	"os"
+)
+
+type programParser interface {
+	Parse(source string, options model.ExecutionOptions) ([]model.Command, error)
+}
+
+func runGraph(args []string) error {
+	flags := flag.NewFlagSet("graph", flag.ContinueOnError)
+	format := flags.String("format", string(graph.FormatDOT), "output format: dot or mermaid")
+	syntax := flags.String("syntax", "script", "program syntax: script or expression")
+	numeric := flags.String("numeric", string(model.NumericInt64), "numeric mode: int64, big or decimal")
+	policy := flags.String("policy", os.Getenv("REASSIGNMENT_POLICY"), "reassignment policy: reject or versioned")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: industrial-calculator graph [flags] [file]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if !graph.IsValidFormat(graph.Format(*format)) {
+		return fmt.Errorf("unknown graph format %q", *format)
+	}
+
+	parsers := map[string]programParser{
+		"script":     script_parser.NewParser(),
+		"expression": expression_parser.NewParser(),
+	}
+
+	parser, ok := parsers[*syntax]
+	if !ok {
+		return fmt.Errorf("unknown program syntax %q", *syntax)
+	}
+
+	options := model.DefaultExecutionOptions()
+	options.Numeric = model.NumericMode(*numeric)
+	if !model.IsValidNumericMode(options.Numeric) {
+		return fmt.Errorf("unknown numeric mode %q", *numeric)
+	}
+
+	resolverPolicy, err := assignment_resolver.ParsePolicy(*policy)
+	if err != nil {
+		return err
+	}
+
+	input := io.Reader(os.Stdin)
+	if flags.NArg() > 0 {
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		input = file
+	}
+
+	source, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+
+	commands, err := parser.Parse(string(source), options)
+	if err != nil {
+		return err
+	}
+
+	planner := usecase.NewCalcExectureUsecase(required_variables_finder.NewFinder(), program_validator.NewValidator(),
+		assignment_resolver.NewResolver(resolverPolicy), scheduler.NewSequentialScheduler(), model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits)
+
+	g, err := graph.NewBuilder(planner).Build(commands)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := graph.Render(g, graph.Format(*format))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(os.Stdout, rendered)
+	return err
+}
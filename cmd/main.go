@@ -1,20 +1,95 @@
 package main
 
 import (
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/expression_parser"
+	"industrial-calculator/internal/graph"
+	"industrial-calculator/internal/job_manager"
+	"industrial-calculator/internal/program_builder"
+	"industrial-calculator/internal/program_validator"
 	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/script_parser"
 	"industrial-calculator/internal/server/grpc"
 	"industrial-calculator/internal/server/http"
 	"industrial-calculator/internal/server/http/handler"
+	"industrial-calculator/internal/session"
 	"industrial-calculator/internal/usecase"
 	"log"
+	"os"
 	"sync"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "graph" {
+		if err := runGraph(os.Args[2:]); err != nil {
+			log.Fatalf("graph: %v", err)
+		}
+
+		return
+	}
+
+	policy, err := assignment_resolver.ParsePolicy(os.Getenv("REASSIGNMENT_POLICY"))
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	poolSize, err := scheduler.ParsePoolSize(os.Getenv("WORKER_POOL_SIZE"))
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	maxTimeout, err := usecase.ParseMaxTimeout(os.Getenv("EXECUTION_TIMEOUT"))
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	maxValueBits, err := usecase.ParseMaxValueBits(os.Getenv("MAX_VALUE_BITS"))
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	mode, err := usecase.ParseExecutionMode(os.Getenv("EXECUTION_MODE"))
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	jobTTL, err := job_manager.ParseTTL(os.Getenv("JOB_TTL"))
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	jobTimeout, err := job_manager.ParseTimeout(os.Getenv("JOB_TIMEOUT"))
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	jobLimit, err := job_manager.ParseLimit(os.Getenv("JOB_LIMIT"))
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
 	finder := required_variables_finder.NewFinder()
-	uc := usecase.NewCalcExectureUsecase(finder)
-	restHandler := handler.NewCalcExecutorHandler(uc)
-	grpcHandler := grpc.NewCalcExecutorServer(uc)
+	validator := program_validator.NewValidator()
+	resolver := assignment_resolver.NewResolver(policy)
+	uc := usecase.NewExecutorRouter(mode,
+		usecase.NewCalcExectureUsecase(finder, validator, resolver, scheduler.NewPoolScheduler(poolSize), maxTimeout,
+			maxValueBits),
+		usecase.NewCalcExectureUsecase(finder, validator, resolver, scheduler.NewSequentialScheduler(), maxTimeout,
+			maxValueBits),
+	)
+	jobs := job_manager.NewManager(uc, jobTTL, jobTimeout, jobLimit)
+	builder := program_builder.NewBuilder(uc)
+	parser := expression_parser.NewParser()
+	scriptParser := script_parser.NewParser()
+	restHandler := handler.NewCalcExecutorHandler(uc, builder, scriptParser)
+	explainHandler := handler.NewExplainHandler(uc, builder, scriptParser)
+	planHandler := handler.NewPlanHandler(uc, builder, scriptParser)
+	expressionHandler := handler.NewExpressionHandler(uc, parser)
+	graphHandler := handler.NewGraphHandler(graph.NewBuilder(uc), builder, scriptParser)
+	jobHandler := handler.NewJobHandler(jobs, builder, scriptParser)
+	sessions := session.NewManager(policy, mode, poolSize, maxTimeout, maxValueBits)
+	grpcHandler := grpc.NewCalcExecutorServer(uc, builder, parser, jobs, sessions)
 
 	var wg sync.WaitGroup
 	wg.Add(2)
@@ -22,7 +97,15 @@ func main() {
 	go func() {
 		defer wg.Done()
 		log.Println("Starting HTTP server on :8080")
-		http.StartHTTPServer(restHandler)
+		http.StartHTTPServer(http.Routes{
+			"/process":            restHandler,
+			"/process/expression": expressionHandler,
+			"/explain":            explainHandler,
+			"/graph":              graphHandler,
+			"/plan":               planHandler,
+			"/jobs":               jobHandler,
+			"/jobs/":              jobHandler,
+		})
 	}()
 
 	go func() {
@@ -0,0 +1,127 @@
+package assignment_resolver
+
+import (
+	"errors"
+	"fmt"
+	"industrial-calculator/internal/model"
+)
+
+const (
+	Reject    Policy = "reject"
+	Versioned Policy = "versioned"
+)
+
+type Policy string
+
+func ParsePolicy(value string) (Policy, error) {
+	switch Policy(value) {
+	case "":
+		return Reject, nil
+	case Reject, Versioned:
+		return Policy(value), nil
+	default:
+		return "", fmt.Errorf("unknown reassignment policy: %q", value)
+	}
+}
+
+type resolver struct {
+	policy Policy
+}
+
+func NewResolver(policy Policy) *resolver {
+	return &resolver{policy: policy}
+}
+
+func (r *resolver) Resolve(commands []model.Command) ([]model.Command, error) {
+	if r.policy == Versioned {
+		return r.version(commands), nil
+	}
+
+	return commands, r.rejectReassignments(commands)
+}
+
+func (r *resolver) rejectReassignments(commands []model.Command) error {
+	firstAssignment := make(map[*model.Variable]int)
+
+	var errs []error
+	for i, cmd := range commands {
+		if !cmd.IsCalc() {
+			continue
+		}
+
+		if first, ok := firstAssignment[cmd.Var]; ok {
+			errs = append(errs, &model.ReassignmentError{
+				Var:               cmd.Var.GetName(),
+				FirstCommandIndex: first,
+				CommandIndex:      i,
+				FirstLine:         commands[first].Line,
+				Line:              cmd.Line,
+				Column:            cmd.Column,
+			})
+			continue
+		}
+
+		firstAssignment[cmd.Var] = i
+	}
+
+	return errors.Join(errs...)
+}
+
+// version gives every calc its own variable; references are bound to the most
+// recent preceding assignment, or to the first one when none precedes them.
+func (r *resolver) version(commands []model.Command) []model.Command {
+	versions := make([]*model.Variable, len(commands))
+	firstVersion := make(map[*model.Variable]*model.Variable)
+
+	for i, cmd := range commands {
+		if !cmd.IsCalc() {
+			continue
+		}
+
+		if _, ok := firstVersion[cmd.Var]; !ok {
+			firstVersion[cmd.Var] = cmd.Var
+			versions[i] = cmd.Var
+			continue
+		}
+
+		versions[i] = model.NewVariable(cmd.Var.GetName())
+	}
+
+	current := make(map[*model.Variable]*model.Variable)
+	lookup := func(variable *model.Variable) *model.Variable {
+		if v, ok := current[variable]; ok {
+			return v
+		}
+
+		if v, ok := firstVersion[variable]; ok {
+			return v
+		}
+
+		return variable
+	}
+
+	resolveArgument := func(argument model.Argument) model.Argument {
+		if variable, ok := argument.(*model.Variable); ok {
+			return lookup(variable)
+		}
+
+		return argument
+	}
+
+	resolved := make([]model.Command, len(commands))
+	for i, cmd := range commands {
+		resolved[i] = cmd
+
+		switch {
+		case cmd.IsPrint():
+			resolved[i].Var = lookup(cmd.Var)
+		case cmd.IsCalc():
+			resolved[i].Left = resolveArgument(cmd.Left)
+			resolved[i].Right = resolveArgument(cmd.Right)
+			resolved[i].Var = versions[i]
+			current[cmd.Var] = versions[i]
+		}
+	}
+
+	return resolved
+}
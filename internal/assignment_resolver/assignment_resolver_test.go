@@ -0,0 +1,99 @@
+package assignment_resolver_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/model"
+	"testing"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		expected  assignment_resolver.Policy
+		expectErr bool
+	}{
+		{name: "default", value: "", expected: assignment_resolver.Reject},
+		{name: "reject", value: "reject", expected: assignment_resolver.Reject},
+		{name: "versioned", value: "versioned", expected: assignment_resolver.Versioned},
+		{name: "unknown", value: "last-wins", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := assignment_resolver.ParsePolicy(tt.value)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, policy)
+		})
+	}
+}
+
+func TestRejectPolicy(t *testing.T) {
+	r := assignment_resolver.NewResolver(assignment_resolver.Reject)
+	x := model.NewVariable("x")
+	y := model.NewVariable("y")
+
+	commands := []model.Command{
+		{Type: model.Calc, Var: x, Op: model.Plus, Left: model.NumericArgument(1), Right: model.NumericArgument(2)},
+		{Type: model.Calc, Var: y, Op: model.Plus, Left: x, Right: model.NumericArgument(2)},
+		{Type: model.Calc, Var: x, Op: model.Plus, Left: model.NumericArgument(3), Right: model.NumericArgument(4)},
+		{Type: model.Print, Var: x},
+		{Type: model.Calc, Var: x, Op: model.Plus, Left: model.NumericArgument(5), Right: model.NumericArgument(6)},
+	}
+
+	_, err := r.Resolve(commands)
+
+	assert.ErrorIs(t, err, model.ErrInvalidProgram)
+	assert.ErrorContains(t, err, `command 2: variable "x" is already assigned by command 0`)
+	assert.ErrorContains(t, err, `command 4: variable "x" is already assigned by command 0`)
+
+	resolved, err := r.Resolve(commands[:2])
+	assert.NoError(t, err)
+	assert.Equal(t, commands[:2], resolved)
+}
+
+func TestVersionedPolicy(t *testing.T) {
+	r := assignment_resolver.NewResolver(assignment_resolver.Versioned)
+	x := model.NewVariable("x")
+	y := model.NewVariable("y")
+
+	commands := []model.Command{
+		{Type: model.Print, Var: x},
+		{Type: model.Calc, Var: x, Op: model.Plus, Left: model.NumericArgument(1), Right: model.NumericArgument(2)},
+		{Type: model.Calc, Var: x, Op: model.Multiply, Left: x, Right: model.NumericArgument(10)},
+		{Type: model.Print, Var: x},
+		{Type: model.Calc, Var: y, Op: model.Minus, Left: x, Right: model.NumericArgument(1)},
+		{Type: model.Calc, Var: x, Op: model.Plus, Left: y, Right: x},
+		{Type: model.Print, Var: x},
+	}
+
+	resolved, err := r.Resolve(commands)
+	assert.NoError(t, err)
+	assert.Len(t, resolved, len(commands))
+
+	first := resolved[1].Var
+	second := resolved[2].Var
+	third := resolved[5].Var
+
+	assert.Same(t, x, first)
+	assert.NotSame(t, first, second)
+	assert.NotSame(t, second, third)
+	assert.Equal(t, "x", second.GetName())
+	assert.Equal(t, "x", third.GetName())
+
+	assert.Same(t, first, resolved[0].Var)
+	assert.Same(t, first, resolved[2].Left)
+	assert.Same(t, second, resolved[3].Var)
+	assert.Same(t, second, resolved[4].Left)
+	assert.Same(t, y, resolved[5].Left)
+	assert.Same(t, second, resolved[5].Right)
+	assert.Same(t, third, resolved[6].Var)
+
+	assert.Same(t, x, commands[2].Var)
+}
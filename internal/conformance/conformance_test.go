@@ -0,0 +1,410 @@
+package conformance_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	api "industrial-calculator/api/industrial-calculator.v1"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/expression_parser"
+	"industrial-calculator/internal/job_manager"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_builder"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/script_parser"
+	"industrial-calculator/internal/server/grpc"
+	"industrial-calculator/internal/server/http/handler"
+	"industrial-calculator/internal/session"
+	"industrial-calculator/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type decimal string
+
+type command struct {
+	Type     string
+	Var      string
+	Op       string
+	Rounding string
+	Left     interface{}
+	Right    interface{}
+}
+
+type result struct {
+	Var    string
+	Value  string
+	Status string
+	Error  string
+}
+
+type violation struct {
+	Code  string
+	Field string
+}
+
+type outcome struct {
+	results      []result
+	invalid      bool
+	errorMessage string
+	violations   []violation
+}
+
+type fixture struct {
+	name     string
+	numeric  model.NumericMode
+	commands []command
+	expected outcome
+	rpcOnly  bool // the literal has no JSON number form
+}
+
+var fixtures = []fixture{
+	{
+		name: "forward reference",
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "+", Left: "y", Right: int64(1)},
+			{Type: "calc", Var: "y", Op: "*", Left: int64(2), Right: int64(3)},
+			{Type: "print", Var: "x"},
+		},
+		expected: outcome{results: []result{{Var: "x", Value: "7", Status: "ok"}}},
+	},
+	{
+		name: "division with rounding mode",
+		commands: []command{
+			{Type: "calc", Var: "q", Op: "/", Rounding: "floor", Left: int64(-7), Right: int64(2)},
+			{Type: "calc", Var: "r", Op: "%", Rounding: "euclid", Left: int64(-7), Right: int64(2)},
+			{Type: "print", Var: "q"},
+			{Type: "print", Var: "r"},
+		},
+		expected: outcome{results: []result{{Var: "q", Value: "-4", Status: "ok"}, {Var: "r", Value: "1", Status: "ok"}}},
+	},
+	{
+		name:    "decimal literals",
+		numeric: model.NumericDecimal,
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "*", Left: decimal("1.25"), Right: "y"},
+			{Type: "calc", Var: "y", Op: "-", Left: decimal("0.5"), Right: int64(3)},
+			{Type: "print", Var: "x"},
+		},
+		expected: outcome{results: []result{{Var: "x", Value: "-3.1250", Status: "ok"}}},
+	},
+	{
+		name:    "big integers",
+		numeric: model.NumericBig,
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "*", Left: int64(9223372036854775807), Right: int64(4)},
+			{Type: "print", Var: "x"},
+		},
+		expected: outcome{results: []result{{Var: "x", Value: "36893488147419103228", Status: "ok"}}},
+	},
+	{
+		name: "calculation error propagates to dependants",
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "/", Left: int64(1), Right: int64(0)},
+			{Type: "calc", Var: "y", Op: "+", Left: "x", Right: int64(1)},
+			{Type: "print", Var: "y"},
+		},
+		expected: outcome{results: []result{{Var: "y", Status: "error", Error: `command 0: cannot calculate "x": division by zero`}}},
+	},
+	{
+		name: "undefined variable",
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "+", Left: int64(1), Right: int64(2)},
+			{Type: "print", Var: "z"},
+		},
+		expected: outcome{invalid: true, errorMessage: `command 1: variable "z" is never assigned`,
+			violations: []violation{{Code: "undefined_variable", Field: "commands[1]"}}},
+	},
+	{
+		name: "dependency cycle",
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "+", Left: "y", Right: int64(1)},
+			{Type: "calc", Var: "y", Op: "+", Left: "x", Right: int64(1)},
+			{Type: "print", Var: "x"},
+		},
+		expected: outcome{invalid: true, errorMessage: "dependency cycle: x -> y -> x",
+			violations: []violation{{Code: "dependency_cycle", Field: "commands"}}},
+	},
+	{
+		name: "reassignment",
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "+", Left: int64(1), Right: int64(2)},
+			{Type: "calc", Var: "x", Op: "+", Left: int64(3), Right: int64(4)},
+		},
+		expected: outcome{invalid: true, errorMessage: `command 1: variable "x" is already assigned by command 0`,
+			violations: []violation{{Code: "reassignment", Field: "commands[1]"}}},
+	},
+	{
+		name: "unknown operation",
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "42", Left: int64(1), Right: int64(2)},
+		},
+		expected: outcome{invalid: true, errorMessage: `command 0: field "op": unknown operation "42"`,
+			violations: []violation{{Code: "unknown_operation", Field: "commands[0].op"}}},
+	},
+	{
+		name: "fractional literal outside decimal mode",
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "+", Left: decimal("1.5"), Right: int64(2)},
+		},
+		expected: outcome{invalid: true, errorMessage: `command 0: field "left": 1.5 is not an integer`,
+			violations: []violation{{Code: "invalid_literal", Field: "commands[0].left"}}},
+	},
+	{
+		name:    "fraction syntax in text literal",
+		numeric: model.NumericBig,
+		rpcOnly: true,
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "+", Left: decimal("4/2"), Right: int64(2)},
+		},
+		expected: outcome{invalid: true, errorMessage: `command 0: field "left": 4/2 is not a valid number`,
+			violations: []violation{{Code: "invalid_literal", Field: "commands[0].left"}}},
+	},
+	{
+		name:    "base prefix in text literal",
+		numeric: model.NumericDecimal,
+		rpcOnly: true,
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "+", Left: int64(2), Right: decimal("0x10")},
+		},
+		expected: outcome{invalid: true, errorMessage: `command 0: field "right": 0x10 is not a valid number`,
+			violations: []violation{{Code: "invalid_literal", Field: "commands[0].right"}}},
+	},
+	{
+		name: "missing operand",
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "+", Left: int64(1)},
+		},
+		expected: outcome{invalid: true, errorMessage: `command 0: field "right": operand is required`,
+			violations: []violation{{Code: "missing_operand", Field: "commands[0].right"}}},
+	},
+	{
+		name: "all validation errors are reported",
+		commands: []command{
+			{Type: "calc", Var: "x", Op: "42", Left: int64(1)},
+			{Type: "print", Var: "x"},
+			{Type: "calc", Var: "y", Op: "+", Left: decimal("0.5"), Right: int64(1)},
+		},
+		expected: outcome{
+			invalid: true,
+			errorMessage: `command 0: field "op": unknown operation "42"` + "\n" +
+				`command 0: field "right": operand is required` + "\n" +
+				`command 2: field "left": 0.5 is not an integer`,
+			violations: []violation{
+				{Code: "unknown_operation", Field: "commands[0].op"},
+				{Code: "missing_operand", Field: "commands[0].right"},
+				{Code: "invalid_literal", Field: "commands[2].left"},
+			},
+		},
+	},
+}
+
+func TestTransportsAgree(t *testing.T) {
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		program_validator.NewValidator(),
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		scheduler.NewPoolScheduler(4),
+		model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits,
+	)
+	builder := program_builder.NewBuilder(uc)
+	restHandler := handler.NewCalcExecutorHandler(uc, builder, script_parser.NewParser())
+	grpcServer := grpc.NewCalcExecutorServer(uc, builder, expression_parser.NewParser(),
+		job_manager.NewManager(uc, job_manager.DefaultTTL, job_manager.DefaultTimeout, job_manager.DefaultLimit),
+		session.NewManager(assignment_resolver.Reject, model.ModeConcurrent, 4, model.DefaultMaxTimeout,
+			model.DefaultMaxValueBits))
+
+	for _, f := range fixtures {
+		t.Run(f.name, func(t *testing.T) {
+			if !f.rpcOnly {
+				assert.Equal(t, f.expected, processREST(t, restHandler, f), "REST")
+			}
+
+			assert.Equal(t, f.expected, processGRPC(t, grpcServer, f), "gRPC")
+		})
+	}
+}
+
+func processREST(t *testing.T, h http.Handler, f fixture) outcome {
+	body := make([]map[string]interface{}, len(f.commands))
+	for i, cmd := range f.commands {
+		body[i] = map[string]interface{}{"type": cmd.Type, "var": cmd.Var}
+		if cmd.Op != "" {
+			body[i]["op"] = cmd.Op
+		}
+
+		if cmd.Rounding != "" {
+			body[i]["rounding"] = cmd.Rounding
+		}
+
+		for field, operand := range map[string]interface{}{"left": cmd.Left, "right": cmd.Right} {
+			if d, ok := operand.(decimal); ok {
+				operand = json.Number(d)
+			}
+
+			if operand != nil {
+				body[i][field] = operand
+			}
+		}
+	}
+
+	encoded, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	target := "/process"
+	if f.numeric != "" {
+		target += "?" + url.Values{"numeric": {string(f.numeric)}}.Encode()
+	}
+
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(encoded))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+		var problem handler.Problem
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+
+		violations := make([]violation, len(problem.Errors))
+		for i, e := range problem.Errors {
+			violations[i] = violation{Code: e.Code, Field: "commands"}
+			if e.CommandIndex != nil {
+				violations[i].Field = fmt.Sprintf("commands[%d]", *e.CommandIndex)
+			}
+
+			if e.Field != "" {
+				violations[i].Field += "." + e.Field
+			}
+		}
+
+		return outcome{invalid: true, errorMessage: problem.Detail, violations: violations}
+	}
+
+	var response handler.Response
+	decoder := json.NewDecoder(w.Body)
+	decoder.UseNumber()
+	require.NoError(t, decoder.Decode(&response))
+
+	results := make([]result, len(response.Items))
+	for i, item := range response.Items {
+		results[i] = result{Var: item.Var, Status: item.Status, Error: item.Error}
+		if item.Error == "" {
+			results[i].Value = fmt.Sprint(item.Value)
+		}
+	}
+
+	return outcome{results: results}
+}
+
+func processGRPC(t *testing.T, server *grpc.CalcExecutorServer, f fixture) outcome {
+	req := &api.ProcessRequest{}
+	switch f.numeric {
+	case model.NumericBig:
+		req.Numeric = api.NumericMode_NUMERIC_BIG
+	case model.NumericDecimal:
+		req.Numeric = api.NumericMode_NUMERIC_DECIMAL
+	}
+
+	for _, cmd := range f.commands {
+		req.Commands = append(req.Commands, buildCommand(t, cmd))
+	}
+
+	response, err := server.Process(context.Background(), req)
+	if err != nil {
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+
+		var violations []violation
+		for _, detail := range st.Details() {
+			if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+				for _, v := range badRequest.FieldViolations {
+					violations = append(violations, violation{Code: v.Reason, Field: v.Field})
+				}
+			}
+		}
+
+		return outcome{invalid: true, errorMessage: st.Message(), violations: violations}
+	}
+
+	results := make([]result, len(response.Results))
+	for i, r := range response.Results {
+		results[i] = result{Var: r.Var, Status: statusName(r.Status), Error: r.Error}
+		if r.Error != "" {
+			continue
+		}
+
+		switch f.numeric {
+		case model.NumericBig:
+			results[i].Value = r.BigValue
+		case model.NumericDecimal:
+			results[i].Value = r.DecimalValue
+		default:
+			results[i].Value = strconv.FormatInt(r.Value, 10)
+		}
+	}
+
+	return outcome{results: results}
+}
+
+func statusName(status api.VariableStatus) string {
+	return strings.ToLower(strings.TrimPrefix(status.String(), "STATUS_"))
+}
+
+func buildCommand(t *testing.T, cmd command) *api.Command {
+	result := &api.Command{
+		Type:     api.CommandType(api.CommandType_value[strings.ToUpper(cmd.Type)]),
+		Var:      cmd.Var,
+		Rounding: api.Rounding(api.Rounding_value[strings.ToUpper(cmd.Rounding)]),
+	}
+
+	symbols := map[string]api.Operation{
+		"+": api.Operation_PLUS,
+		"-": api.Operation_MINUS,
+		"*": api.Operation_MULTIPLY,
+		"/": api.Operation_DIVIDE,
+		"%": api.Operation_MODULO,
+	}
+
+	if op, ok := symbols[cmd.Op]; ok {
+		result.Op = op
+	} else if cmd.Op != "" {
+		value, err := strconv.Atoi(cmd.Op)
+		require.NoError(t, err)
+		result.Op = api.Operation(value)
+	}
+
+	switch v := cmd.Left.(type) {
+	case int64:
+		result.Left = &api.Command_LeftInt{LeftInt: v}
+	case string:
+		result.Left = &api.Command_LeftStr{LeftStr: v}
+	case decimal:
+		result.Left = &api.Command_LeftDecimal{LeftDecimal: string(v)}
+	}
+
+	switch v := cmd.Right.(type) {
+	case int64:
+		result.Right = &api.Command_RightInt{RightInt: v}
+	case string:
+		result.Right = &api.Command_RightStr{RightStr: v}
+	case decimal:
+		result.Right = &api.Command_RightDecimal{RightDecimal: string(v)}
+	}
+
+	return result
+}
@@ -0,0 +1,120 @@
+package conformance_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_builder"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/usecase"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+const differentialPrograms = 500
+
+var (
+	randomOperations = []string{"+", "-", "*", "/", "%"}
+	randomRoundings  = []model.RoundingMode{
+		model.Trunc, model.Floor, model.Ceil, model.Euclid, model.HalfUp, model.HalfEven,
+	}
+	randomOptions = []model.ExecutionOptions{
+		{Overflow: model.OverflowError, Numeric: model.NumericInt64},
+		{Overflow: model.OverflowWrap, Numeric: model.NumericInt64},
+		{Overflow: model.OverflowSaturate, Numeric: model.NumericInt64},
+		{Overflow: model.OverflowError, Numeric: model.NumericBig},
+		{Overflow: model.OverflowError, Numeric: model.NumericDecimal, Scale: 3},
+	}
+)
+
+func TestSequentialExecutorMatchesConcurrent(t *testing.T) {
+	finder := required_variables_finder.NewFinder()
+	validator := program_validator.NewValidator()
+	resolver := assignment_resolver.NewResolver(assignment_resolver.Reject)
+	concurrent := usecase.NewCalcExectureUsecase(finder, validator, resolver, scheduler.NewPoolScheduler(4),
+		model.DefaultMaxTimeout, model.DefaultMaxValueBits)
+	sequential := usecase.NewCalcExectureUsecase(finder, validator, resolver, scheduler.NewSequentialScheduler(),
+		model.DefaultMaxTimeout, model.DefaultMaxValueBits)
+	builder := program_builder.NewBuilder(sequential)
+
+	for seed := int64(0); seed < differentialPrograms; seed++ {
+		random := rand.New(rand.NewSource(seed))
+		instructions := randomProgram(random)
+		options := randomOptions[random.Intn(len(randomOptions))]
+
+		run := func(executor *usecase.CalcExecutorUsecase) []string {
+			commands, err := builder.Build(instructions, options)
+			require.NoError(t, err, "seed %d", seed)
+
+			result, err := executor.ExecuteInstructions(context.Background(), commands, options)
+			require.NoError(t, err, "seed %d", seed)
+
+			return snapshot(result, options)
+		}
+
+		require.Equal(t, run(sequential), run(concurrent), "seed %d", seed)
+	}
+}
+
+func randomProgram(random *rand.Rand) []model.Instruction {
+	size := 1 + random.Intn(40)
+
+	operand := func(i int) model.Operand {
+		if i > 0 && random.Intn(3) > 0 {
+			return model.NameOperand("v" + strconv.Itoa(random.Intn(i)))
+		}
+
+		return model.NumberOperand(strconv.Itoa(random.Intn(41) - 20))
+	}
+
+	instructions := make([]model.Instruction, 0, size*2)
+	for i := 0; i < size; i++ {
+		instructions = append(instructions, model.Instruction{
+			Type:     model.Calc,
+			Var:      "v" + strconv.Itoa(i),
+			Op:       randomOperations[random.Intn(len(randomOperations))],
+			Rounding: randomRoundings[random.Intn(len(randomRoundings))],
+			Left:     operand(i),
+			Right:    operand(i),
+		})
+	}
+
+	random.Shuffle(len(instructions), func(i, j int) {
+		instructions[i], instructions[j] = instructions[j], instructions[i]
+	})
+
+	for i := random.Intn(size + 1); i >= 0; i-- {
+		instructions = append(instructions, model.Instruction{
+			Type: model.Print,
+			Var:  "v" + strconv.Itoa(random.Intn(size)),
+		})
+	}
+
+	return instructions
+}
+
+func snapshot(variables []*model.Variable, options model.ExecutionOptions) []string {
+	values := make([]string, len(variables))
+	for i, variable := range variables {
+		var value interface{}
+		var err error
+
+		switch options.Numeric {
+		case model.NumericBig:
+			value, err = variable.GetBigValue()
+		case model.NumericDecimal:
+			value, err = variable.GetDecimalValue()
+		default:
+			value, err = variable.GetValue()
+		}
+
+		values[i] = fmt.Sprintf("%s %s %v %v", variable.GetName(), variable.Status(), value, err)
+	}
+
+	return values
+}
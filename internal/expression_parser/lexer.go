@@ -0,0 +1,106 @@
+package expression_parser
+
+import (
+	"fmt"
+	"industrial-calculator/internal/model"
+	"unicode"
+)
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNewline
+	tokenIdent
+	tokenNumber
+	tokenOperator
+	tokenAssign
+	tokenLeftParen
+	tokenRightParen
+)
+
+type tokenKind uint8
+
+type token struct {
+	kind   tokenKind
+	text   string
+	line   int
+	column int
+}
+
+func tokenize(source string) ([]token, error) {
+	runes := []rune(source)
+	tokens := make([]token, 0)
+	line, column := 1, 1
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := token{line: line, column: column}
+
+		switch {
+		case r == '\n' || r == ';':
+			start.kind, start.text = tokenNewline, string(r)
+			tokens = append(tokens, start)
+			i++
+			if r == '\n' {
+				line, column = line+1, 1
+				continue
+			}
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+				column++
+			}
+			continue
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			start.kind, start.text = tokenIdent, string(runes[i:j])
+			tokens = append(tokens, start)
+			column += j - i
+			i = j
+			continue
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			if j+1 < len(runes) && runes[j] == '.' && unicode.IsDigit(runes[j+1]) {
+				j++
+				for j < len(runes) && unicode.IsDigit(runes[j]) {
+					j++
+				}
+			}
+			start.kind, start.text = tokenNumber, string(runes[i:j])
+			tokens = append(tokens, start)
+			column += j - i
+			i = j
+			continue
+		case r == '+' || r == '-' || r == '*' || r == '/' || r == '%':
+			start.kind, start.text = tokenOperator, string(r)
+			tokens = append(tokens, start)
+			i++
+		case r == '=':
+			start.kind, start.text = tokenAssign, string(r)
+			tokens = append(tokens, start)
+			i++
+		case r == '(':
+			start.kind, start.text = tokenLeftParen, string(r)
+			tokens = append(tokens, start)
+			i++
+		case r == ')':
+			start.kind, start.text = tokenRightParen, string(r)
+			tokens = append(tokens, start)
+			i++
+		default:
+			return nil, &model.SyntaxError{Line: line, Column: column, Code: model.CodeSyntaxError,
+				Message: fmt.Sprintf("unexpected character %q", r)}
+		}
+
+		column++
+	}
+
+	return append(tokens, token{kind: tokenEOF, line: line, column: column}), nil
+}
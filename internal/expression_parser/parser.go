@@ -0,0 +1,347 @@
+package expression_parser
+
+import (
+	"errors"
+	"fmt"
+	"industrial-calculator/internal/model"
+	"strconv"
+	"strings"
+)
+
+type node interface{}
+
+type literalNode struct {
+	token token
+}
+
+type identNode struct {
+	token token
+}
+
+type binaryNode struct {
+	token token
+	op    model.Operation
+	left  node
+	right node
+}
+
+type reference struct {
+	token        token
+	commandIndex int
+}
+
+type parser struct {
+}
+
+func NewParser() *parser {
+	return &parser{}
+}
+
+func (p *parser) Parse(source string, options model.ExecutionOptions) ([]model.Command, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &state{
+		tokens:   tokens,
+		options:  options,
+		vars:     make(map[string]*model.Variable),
+		assigned: make(map[string]struct{}),
+		commands: make([]model.Command, 0),
+	}
+
+	if err := s.parseProgram(); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, ref := range s.references {
+		if _, ok := s.assigned[ref.token.text]; !ok {
+			errs = append(errs, &model.UndefinedVariableError{
+				Var:          ref.token.text,
+				CommandIndex: ref.commandIndex,
+				Line:         ref.token.line,
+				Column:       ref.token.column,
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return s.commands, nil
+}
+
+type state struct {
+	tokens     []token
+	pos        int
+	options    model.ExecutionOptions
+	vars       map[string]*model.Variable
+	assigned   map[string]struct{}
+	references []reference
+	commands   []model.Command
+	statement  token
+	temporary  int
+}
+
+func (s *state) peek() token {
+	return s.tokens[s.pos]
+}
+
+func (s *state) next() token {
+	t := s.tokens[s.pos]
+	if t.kind != tokenEOF {
+		s.pos++
+	}
+
+	return t
+}
+
+func (s *state) errorAt(t token, format string, args ...interface{}) error {
+	return &model.SyntaxError{Line: t.line, Column: t.column, Code: model.CodeSyntaxError,
+		Message: fmt.Sprintf(format, args...)}
+}
+
+func (s *state) parseProgram() error {
+	for s.peek().kind != tokenEOF {
+		if s.peek().kind == tokenNewline {
+			s.next()
+			continue
+		}
+
+		if err := s.parseStatement(); err != nil {
+			return err
+		}
+
+		if t := s.next(); t.kind != tokenNewline && t.kind != tokenEOF {
+			return s.errorAt(t, "unexpected %q, expected end of statement", t.text)
+		}
+	}
+
+	return nil
+}
+
+func (s *state) parseStatement() error {
+	t := s.next()
+	if t.kind != tokenIdent {
+		return s.errorAt(t, "expected variable name or print, got %s", describe(t))
+	}
+
+	if t.text == "print" && s.peek().kind == tokenIdent {
+		target := s.next()
+		s.reference(identNode{token: target})
+		s.commands = append(s.commands, model.Command{
+			Type:      model.Print,
+			Var:       s.variable(target.text),
+			Line:      target.line,
+			Column:    target.column,
+			Statement: target.text,
+		})
+		return nil
+	}
+
+	if assign := s.next(); assign.kind != tokenAssign {
+		return s.errorAt(assign, "expected \"=\" after %q, got %s", t.text, describe(assign))
+	}
+
+	expr, err := s.parseExpression()
+	if err != nil {
+		return err
+	}
+
+	s.statement = t
+	s.assigned[t.text] = struct{}{}
+
+	return s.lowerInto(s.variable(t.text), expr)
+}
+
+func (s *state) parseExpression() (node, error) {
+	left, err := s.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for t := s.peek(); t.kind == tokenOperator && (t.text == "+" || t.text == "-"); t = s.peek() {
+		s.next()
+
+		right, err := s.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binaryNode{token: t, op: model.GetOperationBySymbol(t.text), left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (s *state) parseTerm() (node, error) {
+	left, err := s.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for t := s.peek(); t.kind == tokenOperator && (t.text == "*" || t.text == "/" || t.text == "%"); t = s.peek() {
+		s.next()
+
+		right, err := s.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binaryNode{token: t, op: model.GetOperationBySymbol(t.text), left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (s *state) parseUnary() (node, error) {
+	t := s.peek()
+	if t.kind != tokenOperator || t.text != "-" {
+		return s.parsePrimary()
+	}
+
+	s.next()
+
+	operand, err := s.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	if literal, ok := operand.(literalNode); ok && !strings.HasPrefix(literal.token.text, "-") {
+		literal.token.text = "-" + literal.token.text
+		return literal, nil
+	}
+
+	zero := literalNode{token: token{kind: tokenNumber, text: "0", line: t.line, column: t.column}}
+	return binaryNode{token: t, op: model.Minus, left: zero, right: operand}, nil
+}
+
+func (s *state) parsePrimary() (node, error) {
+	t := s.next()
+
+	switch t.kind {
+	case tokenNumber:
+		return literalNode{token: t}, nil
+	case tokenIdent:
+		return identNode{token: t}, nil
+	case tokenLeftParen:
+		expr, err := s.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := s.next(); closing.kind != tokenRightParen {
+			return nil, s.errorAt(closing, "expected \")\", got %s", describe(closing))
+		}
+
+		return expr, nil
+	default:
+		return nil, s.errorAt(t, "expected number, variable or \"(\", got %s", describe(t))
+	}
+}
+
+// lowerInto emits the commands computing expr into target; every command,
+// including generated temporaries, is attributed to the source statement.
+func (s *state) lowerInto(target *model.Variable, expr node) error {
+	binary, ok := expr.(binaryNode)
+	if !ok {
+		argument, err := s.lower(expr)
+		if err != nil {
+			return err
+		}
+
+		s.reference(expr)
+		s.commands = append(s.commands, model.Command{
+			Type:      model.Calc,
+			Var:       target,
+			Op:        model.Plus,
+			Rounding:  model.Trunc,
+			Left:      argument,
+			Right:     model.NumericArgument(0),
+			Line:      s.statement.line,
+			Column:    s.statement.column,
+			Statement: s.statement.text,
+		})
+		return nil
+	}
+
+	left, err := s.lower(binary.left)
+	if err != nil {
+		return err
+	}
+
+	right, err := s.lower(binary.right)
+	if err != nil {
+		return err
+	}
+
+	s.reference(binary.left)
+	s.reference(binary.right)
+	s.commands = append(s.commands, model.Command{
+		Type:      model.Calc,
+		Var:       target,
+		Op:        binary.op,
+		Rounding:  model.Trunc,
+		Left:      left,
+		Right:     right,
+		Line:      binary.token.line,
+		Column:    binary.token.column,
+		Statement: s.statement.text,
+	})
+	return nil
+}
+
+func (s *state) reference(expr node) {
+	if ident, ok := expr.(identNode); ok {
+		s.references = append(s.references, reference{token: ident.token, commandIndex: len(s.commands)})
+	}
+}
+
+func (s *state) lower(expr node) (model.Argument, error) {
+	switch n := expr.(type) {
+	case literalNode:
+		return s.literal(n.token)
+	case identNode:
+		return s.variable(n.token.text), nil
+	default:
+		s.temporary++
+		temporary := model.NewVariable("$t" + strconv.Itoa(s.temporary))
+		if err := s.lowerInto(temporary, expr); err != nil {
+			return nil, err
+		}
+
+		return temporary, nil
+	}
+}
+
+func (s *state) literal(t token) (model.Argument, error) {
+	argument, err := model.ParseNumericLiteral(t.text, s.options.Numeric)
+	if err != nil {
+		return nil, &model.SyntaxError{Line: t.line, Column: t.column, Code: model.CodeInvalidLiteral,
+			Message: err.Error()}
+	}
+
+	return argument, nil
+}
+
+func (s *state) variable(name string) *model.Variable {
+	if _, ok := s.vars[name]; !ok {
+		s.vars[name] = model.NewVariable(name)
+	}
+
+	return s.vars[name]
+}
+
+func describe(t token) string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenNewline:
+		return "end of statement"
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
@@ -0,0 +1,215 @@
+package expression_parser_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/expression_parser"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/usecase"
+	"testing"
+)
+
+func TestParseAndExecute(t *testing.T) {
+	tests := []struct {
+		name     string
+		program  string
+		numeric  model.NumericMode
+		expected map[string]string
+	}{
+		{
+			name:     "precedence and parentheses",
+			program:  "a = 4\nb = 5\nx = (a + 3) * b - 2\nprint x",
+			expected: map[string]string{"x": "33"},
+		},
+		{
+			name:     "left associativity",
+			program:  "x = 100 / 10 / 5; y = 10 - 4 - 3; print x; print y",
+			expected: map[string]string{"x": "2", "y": "3"},
+		},
+		{
+			name:     "multiplicative binds tighter than additive",
+			program:  "x = 2 + 3 * 4 % 5\nprint x",
+			expected: map[string]string{"x": "4"},
+		},
+		{
+			name:     "unary minus",
+			program:  "a = 3\nx = -a * -(2 + 1) - -1\nprint x",
+			expected: map[string]string{"x": "10"},
+		},
+		{
+			name:     "forward reference and comments",
+			program:  "# totals\nx = y * 2 # doubled\ny = 21\nprint x",
+			expected: map[string]string{"x": "42"},
+		},
+		{
+			name:     "decimal literals",
+			program:  "x = 1.5 * (2 + 0.25)\nprint x",
+			numeric:  model.NumericDecimal,
+			expected: map[string]string{"x": "3.3750"},
+		},
+		{
+			name:     "big literals",
+			program:  "x = 99999999999999999999 + 1\nprint x",
+			numeric:  model.NumericBig,
+			expected: map[string]string{"x": "100000000000000000000"},
+		},
+	}
+
+	p := expression_parser.NewParser()
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		program_validator.NewValidator(),
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		scheduler.NewPoolScheduler(4),
+		model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits,
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := model.DefaultExecutionOptions()
+			if tt.numeric != "" {
+				options.Numeric = tt.numeric
+			}
+
+			commands, err := p.Parse(tt.program, options)
+			assert.NoError(t, err)
+
+			result, err := uc.ExecuteInstructions(context.Background(), commands, options)
+			assert.NoError(t, err)
+
+			actual := make(map[string]string)
+			for _, v := range result {
+				value, err := v.GetDecimalValue()
+				assert.NoError(t, err)
+				actual[v.GetName()] = value.String()
+			}
+
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParseLowersIntoTemporaries(t *testing.T) {
+	commands, err := expression_parser.NewParser().Parse("a = 1; b = 2\nx = (a + 3) * b - 2",
+		model.DefaultExecutionOptions())
+	assert.NoError(t, err)
+	assert.Len(t, commands, 5)
+	commands = commands[2:]
+
+	assert.Equal(t, model.Plus, commands[0].Op)
+	assert.Equal(t, "$t2", commands[0].Var.GetName())
+	assert.Equal(t, model.Multiply, commands[1].Op)
+	assert.Equal(t, "$t1", commands[1].Var.GetName())
+	assert.Same(t, commands[0].Var, commands[1].Left)
+	assert.Equal(t, model.Minus, commands[2].Op)
+	assert.Equal(t, "x", commands[2].Var.GetName())
+	assert.Same(t, commands[1].Var, commands[2].Left)
+
+	for i, column := range []int{8, 13, 17} {
+		assert.Equal(t, 2, commands[i].Line)
+		assert.Equal(t, column, commands[i].Column)
+		assert.Equal(t, "x", commands[i].Statement)
+	}
+}
+
+func TestParseReportsUndefinedVariables(t *testing.T) {
+	_, err := expression_parser.NewParser().Parse("a = 1\nx = (a + 3) * b - 2\nprint x\nprint y",
+		model.DefaultExecutionOptions())
+	assert.ErrorIs(t, err, model.ErrInvalidProgram)
+
+	var actual []model.UndefinedVariableError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var undefinedErr *model.UndefinedVariableError
+		if errors.As(e, &undefinedErr) {
+			actual = append(actual, *undefinedErr)
+		}
+	}
+
+	assert.Equal(t, []model.UndefinedVariableError{
+		{Var: "b", CommandIndex: 2, Line: 2, Column: 15},
+		{Var: "y", CommandIndex: 5, Line: 4, Column: 7},
+	}, actual)
+}
+
+func TestParseAttributesTemporaryErrorsToStatement(t *testing.T) {
+	options := model.DefaultExecutionOptions()
+	commands, err := expression_parser.NewParser().Parse("a = 1\nx = (a + 3) / (a - 1) - 2\nprint x", options)
+	assert.NoError(t, err)
+
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		program_validator.NewValidator(),
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		scheduler.NewSequentialScheduler(),
+		model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits,
+	)
+
+	result, err := uc.ExecuteInstructions(context.Background(), commands, options)
+	assert.NoError(t, err)
+
+	_, err = result[0].GetValue()
+	assert.ErrorIs(t, err, model.ErrDivisionByZero)
+	assert.EqualError(t, err, `line 2, column 13: cannot calculate "x": division by zero`)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		program  string
+		expected model.SyntaxError
+	}{
+		{
+			name:     "missing assignment",
+			program:  "x 1",
+			expected: model.SyntaxError{Line: 1, Column: 3, Code: model.CodeSyntaxError, Message: `expected "=" after "x", got "1"`},
+		},
+		{
+			name:     "unbalanced parentheses",
+			program:  "a = 1\nx = (a + 1",
+			expected: model.SyntaxError{Line: 2, Column: 11, Code: model.CodeSyntaxError, Message: `expected ")", got end of input`},
+		},
+		{
+			name:     "dangling operator",
+			program:  "x = 1 +\nprint x",
+			expected: model.SyntaxError{Line: 1, Column: 8, Code: model.CodeSyntaxError, Message: `expected number, variable or "(", got end of statement`},
+		},
+		{
+			name:     "unknown character",
+			program:  "x = 2 ^ 3",
+			expected: model.SyntaxError{Line: 1, Column: 7, Code: model.CodeSyntaxError, Message: `unexpected character '^'`},
+		},
+		{
+			name:     "fraction outside decimal mode",
+			program:  "x = 1.5",
+			expected: model.SyntaxError{Line: 1, Column: 5, Code: model.CodeInvalidLiteral, Message: "fractional literal 1.5 requires decimal numeric mode"},
+		},
+		{
+			name:     "literal beyond int64",
+			program:  "x = 9223372036854775808",
+			expected: model.SyntaxError{Line: 1, Column: 5, Code: model.CodeInvalidLiteral, Message: "9223372036854775808 is out of int64 range"},
+		},
+		{
+			name:     "trailing tokens",
+			program:  "x = 1 2",
+			expected: model.SyntaxError{Line: 1, Column: 7, Code: model.CodeSyntaxError, Message: `unexpected "2", expected end of statement`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expression_parser.NewParser().Parse(tt.program, model.DefaultExecutionOptions())
+
+			var syntaxErr *model.SyntaxError
+			assert.True(t, errors.As(err, &syntaxErr))
+			assert.Equal(t, tt.expected, *syntaxErr)
+			assert.ErrorIs(t, err, model.ErrInvalidProgram)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"fmt"
+	"industrial-calculator/internal/model"
+)
+
+const (
+	NodeVariable  NodeKind = "variable"
+	NodeLiteral   NodeKind = "literal"
+	NodeOperation NodeKind = "operation"
+)
+
+type NodeKind string
+
+type Node struct {
+	ID      string
+	Kind    NodeKind
+	Label   string
+	Printed bool
+	Pruned  bool
+}
+
+type Edge struct {
+	From  string
+	To    string
+	Label string
+}
+
+type Graph struct {
+	Nodes []*Node
+	Edges []Edge
+}
+
+type programPlanner interface {
+	PlanInstructions(commands []model.Command) (*model.Plan, error)
+}
+
+type builder struct {
+	planner programPlanner
+}
+
+func NewBuilder(planner programPlanner) *builder {
+	return &builder{planner: planner}
+}
+
+func (b *builder) Build(commands []model.Command) (*Graph, error) {
+	plan, err := b.planner.PlanInstructions(commands)
+	if err != nil {
+		return nil, err
+	}
+
+	commands = plan.Commands
+	live := make(map[int]struct{}, len(plan.Required))
+	required := make(map[*model.Variable]struct{}, len(plan.Required))
+	for _, planned := range plan.Required {
+		live[planned.CommandIndex] = struct{}{}
+		required[commands[planned.CommandIndex].Var] = struct{}{}
+	}
+
+	g := &Graph{}
+	variables := make(map[*model.Variable]*Node)
+
+	variableNode := func(variable *model.Variable) *Node {
+		if node, ok := variables[variable]; ok {
+			return node
+		}
+
+		_, isRequired := required[variable]
+		node := &Node{
+			ID:     fmt.Sprintf("v%d", len(variables)),
+			Kind:   NodeVariable,
+			Label:  variable.GetName(),
+			Pruned: !isRequired,
+		}
+
+		variables[variable] = node
+		g.Nodes = append(g.Nodes, node)
+
+		return node
+	}
+
+	for i, cmd := range commands {
+		if cmd.IsPrint() {
+			node := variableNode(cmd.Var)
+			node.Printed = true
+			node.Pruned = false
+			continue
+		}
+
+		_, isLive := live[i]
+		pruned := !isLive
+
+		operation := &Node{
+			ID:     fmt.Sprintf("op%d", i),
+			Kind:   NodeOperation,
+			Label:  operationLabel(cmd),
+			Pruned: pruned,
+		}
+		g.Nodes = append(g.Nodes, operation)
+
+		for _, operand := range []struct {
+			side     string
+			argument model.Argument
+		}{{"left", cmd.Left}, {"right", cmd.Right}} {
+			if variable, ok := operand.argument.(*model.Variable); ok {
+				g.Edges = append(g.Edges, Edge{From: variableNode(variable).ID, To: operation.ID, Label: operand.side})
+				continue
+			}
+
+			literal := &Node{
+				ID:     fmt.Sprintf("l%d%s", i, operand.side),
+				Kind:   NodeLiteral,
+				Label:  literalLabel(operand.argument),
+				Pruned: pruned,
+			}
+			g.Nodes = append(g.Nodes, literal)
+			g.Edges = append(g.Edges, Edge{From: literal.ID, To: operation.ID, Label: operand.side})
+		}
+
+		g.Edges = append(g.Edges, Edge{From: operation.ID, To: variableNode(cmd.Var).ID})
+	}
+
+	return g, nil
+}
+
+func operationLabel(cmd model.Command) string {
+	if cmd.Rounding == "" || cmd.Rounding == model.Trunc {
+		return cmd.Op.Symbol()
+	}
+
+	return cmd.Op.Symbol() + " " + string(cmd.Rounding)
+}
+
+func literalLabel(argument model.Argument) string {
+	value, err := argument.GetDecimalValue()
+	if err != nil {
+		return "?"
+	}
+
+	return value.String()
+}
@@ -0,0 +1,148 @@
+package graph_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/graph"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/script_parser"
+	"industrial-calculator/internal/usecase"
+	"testing"
+)
+
+const program = `calc x = a + 1
+calc a = 2 * 3
+calc dead = x / 2 floor
+print x`
+
+func buildGraphWithPolicy(t *testing.T, source string, policy assignment_resolver.Policy) (*graph.Graph, error) {
+	commands, err := script_parser.NewParser().Parse(source, model.DefaultExecutionOptions())
+	require.NoError(t, err)
+
+	planner := usecase.NewCalcExectureUsecase(required_variables_finder.NewFinder(), program_validator.NewValidator(),
+		assignment_resolver.NewResolver(policy), scheduler.NewSequentialScheduler(), model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits)
+
+	return graph.NewBuilder(planner).Build(commands)
+}
+
+func buildGraph(t *testing.T, source string) *graph.Graph {
+	g, err := buildGraphWithPolicy(t, source, assignment_resolver.Reject)
+	require.NoError(t, err)
+
+	return g
+}
+
+func TestBuildMarksPrunedNodes(t *testing.T) {
+	g := buildGraph(t, program)
+
+	pruned := make(map[string]bool)
+	printed := make(map[string]bool)
+	for _, node := range g.Nodes {
+		pruned[node.Label+"/"+string(node.Kind)] = node.Pruned
+		printed[node.Label] = printed[node.Label] || node.Printed
+	}
+
+	assert.False(t, pruned["x/variable"])
+	assert.False(t, pruned["a/variable"])
+	assert.False(t, pruned["*/operation"])
+	assert.True(t, pruned["dead/variable"])
+	assert.True(t, pruned["/ floor/operation"])
+	assert.True(t, printed["x"])
+	assert.False(t, printed["a"])
+}
+
+func TestBuildFollowsReassignmentPolicy(t *testing.T) {
+	source := "calc x = 1 + 0\ncalc y = x + 1\ncalc x = 5 + 0\nprint y"
+
+	g, err := buildGraphWithPolicy(t, source, assignment_resolver.Versioned)
+	require.NoError(t, err)
+
+	pruned := make(map[string]bool)
+	for _, node := range g.Nodes {
+		pruned[node.ID] = node.Pruned
+	}
+
+	assert.False(t, pruned["op0"])
+	assert.False(t, pruned["op1"])
+	assert.True(t, pruned["op2"])
+
+	_, err = buildGraphWithPolicy(t, source, assignment_resolver.Reject)
+	assert.ErrorIs(t, err, model.ErrInvalidProgram)
+}
+
+func TestRenderDOT(t *testing.T) {
+	expected := `digraph program {
+  rankdir=LR;
+  op0 [label="+", shape=box];
+  v0 [label="a", shape=ellipse];
+  l0right [label="1", shape=plaintext];
+  v1 [label="x", shape=ellipse, peripheries=2];
+  op1 [label="*", shape=box];
+  l1left [label="2", shape=plaintext];
+  l1right [label="3", shape=plaintext];
+  op2 [label="/ floor", shape=box, style=dashed, color=gray, fontcolor=gray];
+  l2right [label="2", shape=plaintext, style=dashed, color=gray, fontcolor=gray];
+  v2 [label="dead", shape=ellipse, style=dashed, color=gray, fontcolor=gray];
+  v0 -> op0 [label="left"];
+  l0right -> op0 [label="right"];
+  op0 -> v1;
+  l1left -> op1 [label="left"];
+  l1right -> op1 [label="right"];
+  op1 -> v0;
+  v1 -> op2 [label="left"];
+  l2right -> op2 [label="right"];
+  op2 -> v2;
+}
+`
+
+	rendered, err := graph.Render(buildGraph(t, program), graph.FormatDOT)
+	require.NoError(t, err)
+	assert.Equal(t, expected, rendered)
+}
+
+func TestRenderMermaid(t *testing.T) {
+	expected := `flowchart LR
+  op0["+"]
+  v0(["a"])
+  l0right>"1"]
+  v1(["x"])
+  op1["*"]
+  l1left>"2"]
+  l1right>"3"]
+  op2["/ floor"]
+  l2right>"2"]
+  v2(["dead"])
+  v0 -- left --> op0
+  l0right -- right --> op0
+  op0 --> v1
+  l1left -- left --> op1
+  l1right -- right --> op1
+  op1 --> v0
+  v1 -- left --> op2
+  l2right -- right --> op2
+  op2 --> v2
+  classDef printed stroke-width:3px
+  class v1 printed
+  classDef pruned stroke-dasharray:5 5,color:#999
+  class op2,l2right,v2 pruned
+`
+
+	rendered, err := graph.Render(buildGraph(t, program), graph.FormatMermaid)
+	require.NoError(t, err)
+	assert.Equal(t, expected, rendered)
+}
+
+func TestRenderEscapesLabels(t *testing.T) {
+	g := &graph.Graph{Nodes: []*graph.Node{{ID: "v0", Kind: graph.NodeVariable, Label: `a"b\c`}}}
+
+	assert.Contains(t, graph.RenderDOT(g), `v0 [label="a\"b\\c", shape=ellipse];`)
+	assert.Contains(t, graph.RenderMermaid(g), `v0(["a#quot;b\c"])`)
+
+	_, err := graph.Render(g, "svg")
+	assert.Error(t, err)
+}
@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	FormatDOT     Format = "dot"
+	FormatMermaid Format = "mermaid"
+)
+
+type Format string
+
+func IsValidFormat(format Format) bool {
+	switch format {
+	case FormatDOT, FormatMermaid:
+		return true
+	default:
+		return false
+	}
+}
+
+func Render(g *Graph, format Format) (string, error) {
+	switch format {
+	case FormatDOT:
+		return RenderDOT(g), nil
+	case FormatMermaid:
+		return RenderMermaid(g), nil
+	default:
+		return "", fmt.Errorf("unknown graph format %q", format)
+	}
+}
+
+func RenderDOT(g *Graph) string {
+	var b strings.Builder
+
+	b.WriteString("digraph program {\n")
+	b.WriteString("  rankdir=LR;\n")
+
+	for _, node := range g.Nodes {
+		attributes := []string{"label=" + dotQuote(node.Label)}
+
+		switch node.Kind {
+		case NodeVariable:
+			attributes = append(attributes, "shape=ellipse")
+		case NodeOperation:
+			attributes = append(attributes, "shape=box")
+		case NodeLiteral:
+			attributes = append(attributes, "shape=plaintext")
+		}
+
+		if node.Printed {
+			attributes = append(attributes, "peripheries=2")
+		}
+
+		if node.Pruned {
+			attributes = append(attributes, "style=dashed", "color=gray", "fontcolor=gray")
+		}
+
+		fmt.Fprintf(&b, "  %s [%s];\n", node.ID, strings.Join(attributes, ", "))
+	}
+
+	for _, edge := range g.Edges {
+		if edge.Label == "" {
+			fmt.Fprintf(&b, "  %s -> %s;\n", edge.From, edge.To)
+			continue
+		}
+
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", edge.From, edge.To, dotQuote(edge.Label))
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func RenderMermaid(g *Graph) string {
+	var b strings.Builder
+
+	b.WriteString("flowchart LR\n")
+
+	var printed, pruned []string
+	for _, node := range g.Nodes {
+		label := mermaidQuote(node.Label)
+
+		switch node.Kind {
+		case NodeVariable:
+			fmt.Fprintf(&b, "  %s([%s])\n", node.ID, label)
+		case NodeOperation:
+			fmt.Fprintf(&b, "  %s[%s]\n", node.ID, label)
+		case NodeLiteral:
+			fmt.Fprintf(&b, "  %s>%s]\n", node.ID, label)
+		}
+
+		if node.Printed {
+			printed = append(printed, node.ID)
+		}
+
+		if node.Pruned {
+			pruned = append(pruned, node.ID)
+		}
+	}
+
+	for _, edge := range g.Edges {
+		if edge.Label == "" {
+			fmt.Fprintf(&b, "  %s --> %s\n", edge.From, edge.To)
+			continue
+		}
+
+		fmt.Fprintf(&b, "  %s -- %s --> %s\n", edge.From, edge.Label, edge.To)
+	}
+
+	if len(printed) > 0 {
+		b.WriteString("  classDef printed stroke-width:3px\n")
+		fmt.Fprintf(&b, "  class %s printed\n", strings.Join(printed, ","))
+	}
+
+	if len(pruned) > 0 {
+		b.WriteString("  classDef pruned stroke-dasharray:5 5,color:#999\n")
+		fmt.Fprintf(&b, "  class %s pruned\n", strings.Join(pruned, ","))
+	}
+
+	return b.String()
+}
+
+func dotQuote(label string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(label) + `"`
+}
+
+func mermaidQuote(label string) string {
+	return `"` + strings.ReplaceAll(label, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,219 @@
+package job_manager
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"industrial-calculator/internal/model"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTTL     = 10 * time.Minute
+	DefaultTimeout = time.Hour
+	DefaultLimit   = 100
+)
+
+type calcExecutor interface {
+	StartInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+	) ([]*model.Variable, <-chan int, error)
+}
+
+type job struct {
+	model.Job
+	progress  *model.Progress
+	cancel    context.CancelFunc
+	cancelled bool
+	done      chan struct{}
+}
+
+type manager struct {
+	executor calcExecutor
+	ttl      time.Duration
+	timeout  time.Duration
+	limit    int
+	mu       sync.Mutex
+	jobs     map[string]*job
+	running  int
+}
+
+func NewManager(executor calcExecutor, ttl, timeout time.Duration, limit int) *manager {
+	return &manager{executor: executor, ttl: ttl, timeout: timeout, limit: limit, jobs: make(map[string]*job)}
+}
+
+func ParseTTL(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("invalid job ttl: %q", value)
+	}
+
+	return ttl, nil
+}
+
+func ParseTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultTimeout, nil
+	}
+
+	timeout, err := model.ParseTimeout(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid job timeout: %q", value)
+	}
+
+	return timeout, nil
+}
+
+func ParseLimit(value string) (int, error) {
+	if value == "" {
+		return DefaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("invalid job limit: %q", value)
+	}
+
+	return limit, nil
+}
+
+func (m *manager) Submit(commands []model.Command, options model.ExecutionOptions) (model.Job, error) {
+	m.mu.Lock()
+	if m.running >= m.limit {
+		m.mu.Unlock()
+		return model.Job{}, model.ErrTooManyJobs
+	}
+	m.running++
+	m.mu.Unlock()
+
+	j, resolved, err := m.start(commands, options)
+	if err != nil {
+		m.mu.Lock()
+		m.running--
+		m.mu.Unlock()
+
+		return model.Job{}, err
+	}
+
+	m.mu.Lock()
+	m.jobs[j.ID] = j
+	snapshot := m.snapshot(j)
+	m.mu.Unlock()
+
+	go m.run(j, resolved)
+
+	return snapshot, nil
+}
+
+func (m *manager) Get(id string) (model.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return model.Job{}, model.ErrJobNotFound
+	}
+
+	return m.snapshot(j), nil
+}
+
+func (m *manager) Cancel(id string) (model.Job, error) {
+	m.mu.Lock()
+	j, ok := m.jobs[id]
+	if ok && !j.IsFinished() {
+		j.cancelled = true
+		j.cancel()
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		return model.Job{}, model.ErrJobNotFound
+	}
+
+	<-j.done
+
+	return m.Get(id)
+}
+
+func (m *manager) start(commands []model.Command, options model.ExecutionOptions) (*job, <-chan int, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{
+		Job: model.Job{
+			ID:        id,
+			Status:    model.JobRunning,
+			Options:   options,
+			CreatedAt: time.Now(),
+		},
+		progress: model.NewProgress(),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	options.Progress = j.progress
+	options.MaxTimeout = m.timeout
+	results, resolved, err := m.executor.StartInstructions(ctx, commands, options)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	j.Results = results
+
+	return j, resolved, nil
+}
+
+func (m *manager) run(j *job, resolved <-chan int) {
+	defer close(j.done)
+	defer j.cancel()
+
+	// the executor closes the feed once the run is over
+	for range resolved {
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.running--
+	j.FinishedAt = time.Now()
+
+	switch {
+	case j.cancelled:
+		j.Status = model.JobCancelled
+	default:
+		j.Status = model.JobDone
+	}
+
+	time.AfterFunc(m.ttl, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		delete(m.jobs, j.ID)
+	})
+}
+
+func (m *manager) snapshot(j *job) model.Job {
+	snapshot := j.Job
+	snapshot.Resolved, snapshot.Required = j.progress.Counts()
+
+	return snapshot
+}
+
+func newID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,234 @@
+package job_manager_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"industrial-calculator/internal/job_manager"
+	"industrial-calculator/internal/model"
+	"testing"
+	"time"
+)
+
+type blockingExecutor struct {
+	variables []*model.Variable
+	release   chan struct{}
+	startErr  error
+}
+
+func newBlockingExecutor(names ...string) *blockingExecutor {
+	executor := &blockingExecutor{release: make(chan struct{})}
+	for _, name := range names {
+		executor.variables = append(executor.variables, model.NewVariable(name))
+	}
+
+	return executor
+}
+
+func (e *blockingExecutor) StartInstructions(ctx context.Context, commands []model.Command,
+	options model.ExecutionOptions,
+) ([]*model.Variable, <-chan int, error) {
+	if e.startErr != nil {
+		return nil, nil, e.startErr
+	}
+
+	options.Progress.Track(len(e.variables))
+
+	resolve := func(variable *model.Variable, set func()) {
+		if !variable.IsResolved() {
+			set()
+			options.Progress.Resolve()
+		}
+	}
+
+	resolved := make(chan int)
+	go func() {
+		defer close(resolved)
+
+		resolve(e.variables[0], func() { e.variables[0].SetValue(1) })
+
+		select {
+		case <-e.release:
+			for _, variable := range e.variables {
+				resolve(variable, func() { variable.SetValue(2) })
+			}
+		case <-ctx.Done():
+			for _, variable := range e.variables {
+				resolve(variable, func() { variable.SetError(model.CancellationCause(ctx.Err())) })
+			}
+		}
+	}()
+
+	return e.variables, resolved, nil
+}
+
+func TestManagerRunsJob(t *testing.T) {
+	executor := newBlockingExecutor("x", "y", "z")
+	manager := job_manager.NewManager(executor, time.Hour, time.Hour, job_manager.DefaultLimit)
+
+	job, err := manager.Submit(nil, model.DefaultExecutionOptions())
+	require.NoError(t, err)
+	assert.NotEmpty(t, job.ID)
+	assert.Equal(t, model.JobRunning, job.Status)
+	assert.Equal(t, 3, job.Required)
+
+	require.Eventually(t, func() bool {
+		job, err := manager.Get(job.ID)
+		return err == nil && job.Resolved == 1
+	}, time.Second, time.Millisecond)
+
+	close(executor.release)
+
+	require.Eventually(t, func() bool {
+		job, err = manager.Get(job.ID)
+		return err == nil && job.IsFinished()
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, model.JobDone, job.Status)
+	assert.Equal(t, 3, job.Resolved)
+	assert.Len(t, job.Results, 3)
+	assert.NoError(t, job.Err)
+	assert.False(t, job.FinishedAt.IsZero())
+}
+
+func TestManagerCancelsJob(t *testing.T) {
+	manager := job_manager.NewManager(newBlockingExecutor("x", "y"), time.Hour, time.Hour, job_manager.DefaultLimit)
+
+	job, err := manager.Submit(nil, model.DefaultExecutionOptions())
+	require.NoError(t, err)
+
+	job, err = manager.Cancel(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.JobCancelled, job.Status)
+	assert.Equal(t, model.StatusOK, job.Results[0].Status())
+	assert.Equal(t, model.StatusCancelled, job.Results[1].Status())
+
+	job, err = manager.Cancel(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.JobCancelled, job.Status)
+}
+
+func TestManagerEvictsFinishedJobs(t *testing.T) {
+	executor := newBlockingExecutor("x")
+	close(executor.release)
+	manager := job_manager.NewManager(executor, 10*time.Millisecond, time.Hour, job_manager.DefaultLimit)
+
+	job, err := manager.Submit(nil, model.DefaultExecutionOptions())
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := manager.Get(job.ID)
+		return errors.Is(err, model.ErrJobNotFound)
+	}, time.Second, time.Millisecond)
+
+	_, err = manager.Cancel(job.ID)
+	assert.ErrorIs(t, err, model.ErrJobNotFound)
+}
+
+func TestManagerRejectsInvalidProgram(t *testing.T) {
+	executor := newBlockingExecutor()
+	executor.startErr = &model.UndefinedVariableError{Var: "x", CommandIndex: 0}
+	manager := job_manager.NewManager(executor, time.Hour, time.Hour, job_manager.DefaultLimit)
+
+	_, err := manager.Submit(nil, model.DefaultExecutionOptions())
+	assert.ErrorIs(t, err, model.ErrInvalidProgram)
+}
+
+func TestManagerLimitsRunningJobs(t *testing.T) {
+	executor := newBlockingExecutor("x")
+	manager := job_manager.NewManager(executor, time.Hour, time.Hour, 1)
+
+	job, err := manager.Submit(nil, model.DefaultExecutionOptions())
+	require.NoError(t, err)
+
+	_, err = manager.Submit(nil, model.DefaultExecutionOptions())
+	assert.ErrorIs(t, err, model.ErrTooManyJobs)
+
+	_, err = manager.Cancel(job.ID)
+	require.NoError(t, err)
+
+	_, err = manager.Submit(nil, model.DefaultExecutionOptions())
+	assert.NoError(t, err)
+}
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    time.Duration
+		expectedErr bool
+	}{
+		{name: "default", value: "", expected: job_manager.DefaultTTL},
+		{name: "duration", value: "90s", expected: 90 * time.Second},
+		{name: "zero", value: "0s", expectedErr: true},
+		{name: "invalid", value: "soon", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, err := job_manager.ParseTTL(tt.value)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ttl)
+		})
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    time.Duration
+		expectedErr bool
+	}{
+		{name: "default", value: "", expected: job_manager.DefaultTimeout},
+		{name: "duration", value: "2h", expected: 2 * time.Hour},
+		{name: "zero", value: "0s", expectedErr: true},
+		{name: "invalid", value: "later", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout, err := job_manager.ParseTimeout(tt.value)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, timeout)
+		})
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    int
+		expectedErr bool
+	}{
+		{name: "default", value: "", expected: job_manager.DefaultLimit},
+		{name: "number", value: "8", expected: 8},
+		{name: "zero", value: "0", expectedErr: true},
+		{name: "invalid", value: "many", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, err := job_manager.ParseLimit(tt.value)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, limit)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package model
 
+import "math/big"
+
 type Argument interface {
-	GetValue() int64
+	GetValue() (int64, error)
+	GetBigValue() (*big.Int, error)
+	GetDecimalValue() (Decimal, error)
 	HasDependency() bool
 }
@@ -1,11 +1,15 @@
 package model
 
 type Command struct {
-	Type  CommandType
-	Var   *Variable
-	Op    Operation
-	Left  Argument
-	Right Argument
+	Type      CommandType
+	Var       *Variable
+	Op        Operation
+	Rounding  RoundingMode
+	Left      Argument
+	Right     Argument
+	Line      int
+	Column    int
+	Statement string
 }
 
 const (
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+const (
+	DefaultDecimalScale int32 = 4
+	MaxDecimalScale     int32 = 30
+)
+
+var decimalPattern = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int32
+}
+
+func IsDecimalLiteral(s string) bool {
+	return decimalPattern.MatchString(s)
+}
+
+func ParseDecimal(s string) (Decimal, error) {
+	if !IsDecimalLiteral(s) {
+		return Decimal{}, fmt.Errorf("invalid decimal literal: %q", s)
+	}
+
+	integer, fraction, _ := strings.Cut(s, ".")
+	unscaled, ok := new(big.Int).SetString(integer+fraction, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal literal: %q", s)
+	}
+
+	return Decimal{Unscaled: unscaled, Scale: int32(len(fraction))}, nil
+}
+
+func (d Decimal) IsInteger() bool {
+	if d.Scale == 0 {
+		return true
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Scale)), nil)
+	return new(big.Int).Rem(d.Unscaled, divisor).Sign() == 0
+}
+
+func (d Decimal) Integer() *big.Int {
+	if d.Scale == 0 {
+		return d.Unscaled
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Scale)), nil)
+	return new(big.Int).Quo(d.Unscaled, divisor)
+}
+
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.Unscaled).String()
+
+	sign := ""
+	if d.Unscaled.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.Scale <= 0 {
+		return sign + digits
+	}
+
+	if pad := int(d.Scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	point := len(digits) - int(d.Scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
@@ -0,0 +1,40 @@
+package model
+
+import "math/big"
+
+type DecimalArgument struct {
+	value Decimal
+}
+
+func NewDecimalArgument(value Decimal) DecimalArgument {
+	return DecimalArgument{value: value}
+}
+
+func (d DecimalArgument) GetValue() (int64, error) {
+	value, err := d.GetBigValue()
+	if err != nil {
+		return 0, err
+	}
+
+	if !value.IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	return value.Int64(), nil
+}
+
+func (d DecimalArgument) GetBigValue() (*big.Int, error) {
+	if !d.value.IsInteger() {
+		return nil, ErrFractionalValue
+	}
+
+	return d.value.Integer(), nil
+}
+
+func (d DecimalArgument) GetDecimalValue() (Decimal, error) {
+	return d.value, nil
+}
+
+func (d DecimalArgument) HasDependency() bool {
+	return false
+}
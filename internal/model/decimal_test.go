@@ -0,0 +1,44 @@
+package model_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/model"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		name            string
+		literal         string
+		expectedValid   bool
+		expectedScale   int32
+		expectedString  string
+		expectedInteger bool
+	}{
+		{name: "integer", literal: "42", expectedValid: true, expectedString: "42", expectedInteger: true},
+		{name: "fraction", literal: "3.1415", expectedValid: true, expectedScale: 4, expectedString: "3.1415"},
+		{name: "negative below one", literal: "-0.05", expectedValid: true, expectedScale: 2, expectedString: "-0.05"},
+		{name: "explicit plus", literal: "+1.50", expectedValid: true, expectedScale: 2, expectedString: "1.50"},
+		{name: "zero fraction", literal: "2.000", expectedValid: true, expectedScale: 3, expectedString: "2.000", expectedInteger: true},
+		{name: "missing fraction digits", literal: "1.", expectedValid: false},
+		{name: "exponent", literal: "1e5", expectedValid: false},
+		{name: "variable name", literal: "x", expectedValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedValid, model.IsDecimalLiteral(tt.literal))
+
+			decimal, err := model.ParseDecimal(tt.literal)
+			if !tt.expectedValid {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedScale, decimal.Scale)
+			assert.Equal(t, tt.expectedString, decimal.String())
+			assert.Equal(t, tt.expectedInteger, decimal.IsInteger())
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package model
+
+type Derivation struct {
+	Variable     *Variable
+	Literal      Argument
+	CommandIndex int
+	Op           Operation
+	Rounding     RoundingMode
+	Left         *Derivation
+	Right        *Derivation
+	Shared       bool
+}
+
+func (d *Derivation) IsLiteral() bool {
+	return d.Variable == nil
+}
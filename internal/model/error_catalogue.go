@@ -0,0 +1,142 @@
+package model
+
+import "errors"
+
+const (
+	CodeInvalidRequest      ErrorCode = "invalid_request"
+	CodeMethodNotAllowed    ErrorCode = "method_not_allowed"
+	CodeProgramTooLarge     ErrorCode = "program_too_large"
+	CodeInvalidProgram      ErrorCode = "invalid_program"
+	CodeInvalidOption       ErrorCode = "invalid_option"
+	CodeUnknownCommandType  ErrorCode = "unknown_command_type"
+	CodeUnknownOperation    ErrorCode = "unknown_operation"
+	CodeUnknownRoundingMode ErrorCode = "unknown_rounding_mode"
+	CodeMissingOperand      ErrorCode = "missing_operand"
+	CodeInvalidOperand      ErrorCode = "invalid_operand"
+	CodeInvalidLiteral      ErrorCode = "invalid_literal"
+	CodeSyntaxError         ErrorCode = "syntax_error"
+	CodeUndefinedVariable   ErrorCode = "undefined_variable"
+	CodeDependencyCycle     ErrorCode = "dependency_cycle"
+	CodeReassignment        ErrorCode = "reassignment"
+	CodeDivisionByZero      ErrorCode = "division_by_zero"
+	CodeOverflow            ErrorCode = "overflow"
+	CodeFractionalValue     ErrorCode = "fractional_value"
+	CodeCalculationFailed   ErrorCode = "calculation_failed"
+	CodeJobNotFound         ErrorCode = "job_not_found"
+	CodeTooManyJobs         ErrorCode = "too_many_jobs"
+	CodeInternal            ErrorCode = "internal"
+)
+
+const NoCommandIndex = -1
+
+type ErrorCode string
+
+type ErrorDetail struct {
+	Code         ErrorCode
+	Message      string
+	CommandIndex int
+	Field        string
+	Var          string
+	Line         int
+	Column       int
+}
+
+func ErrorDetails(err error) []ErrorDetail {
+	if err == nil {
+		return nil
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		details := make([]ErrorDetail, 0)
+		for _, e := range joined.Unwrap() {
+			details = append(details, ErrorDetails(e)...)
+		}
+
+		return details
+	}
+
+	return []ErrorDetail{describe(err)}
+}
+
+func describe(err error) ErrorDetail {
+	detail := ErrorDetail{Message: err.Error(), CommandIndex: NoCommandIndex}
+
+	var (
+		fieldErr       *FieldError
+		optionErr      *OptionError
+		syntaxErr      *SyntaxError
+		undefinedErr   *UndefinedVariableError
+		cycleErr       *CycleError
+		reassignErr    *ReassignmentError
+		calculationErr *CalculationError
+	)
+
+	switch {
+	case errors.As(err, &fieldErr):
+		detail.Code = fieldErr.Code
+		detail.CommandIndex = fieldErr.CommandIndex
+		detail.Field = fieldErr.Field
+	case errors.As(err, &optionErr):
+		detail.Code = CodeInvalidOption
+		detail.Field = optionErr.Option
+	case errors.As(err, &syntaxErr):
+		detail.Code = syntaxErr.Code
+		if detail.Code == "" {
+			detail.Code = CodeSyntaxError
+		}
+		detail.Line = syntaxErr.Line
+		detail.Column = syntaxErr.Column
+	case errors.As(err, &undefinedErr):
+		detail.Code = CodeUndefinedVariable
+		detail.Var = undefinedErr.Var
+		detail.Line = undefinedErr.Line
+		detail.Column = undefinedErr.Column
+		if undefinedErr.Line == 0 {
+			detail.CommandIndex = undefinedErr.CommandIndex
+		}
+	case errors.As(err, &cycleErr):
+		detail.Code = CodeDependencyCycle
+		detail.Line = cycleErr.Line
+		detail.Column = cycleErr.Column
+		if len(cycleErr.Path) > 0 {
+			detail.Var = cycleErr.Path[0]
+		}
+	case errors.As(err, &reassignErr):
+		detail.Code = CodeReassignment
+		detail.Var = reassignErr.Var
+		detail.Line = reassignErr.Line
+		detail.Column = reassignErr.Column
+		if reassignErr.Line == 0 {
+			detail.CommandIndex = reassignErr.CommandIndex
+		}
+	case errors.As(err, &calculationErr):
+		detail.Code = calculationCode(calculationErr.Err)
+		detail.Var = calculationErr.Var
+		detail.Line = calculationErr.Line
+		detail.Column = calculationErr.Column
+		if calculationErr.Line == 0 {
+			detail.CommandIndex = calculationErr.CommandIndex
+		}
+	default:
+		detail.Code = CodeInternal
+	}
+
+	if detail.Code == "" {
+		detail.Code = CodeInvalidRequest
+	}
+
+	return detail
+}
+
+func calculationCode(err error) ErrorCode {
+	switch {
+	case errors.Is(err, ErrDivisionByZero):
+		return CodeDivisionByZero
+	case errors.Is(err, ErrOverflow):
+		return CodeOverflow
+	case errors.Is(err, ErrFractionalValue):
+		return CodeFractionalValue
+	default:
+		return CodeCalculationFailed
+	}
+}
@@ -0,0 +1,56 @@
+package model_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/model"
+	"testing"
+)
+
+func TestErrorDetails(t *testing.T) {
+	err := errors.Join(
+		&model.ReassignmentError{Var: "x", FirstCommandIndex: 0, CommandIndex: 2},
+		errors.Join(
+			&model.UndefinedVariableError{Var: "y", CommandIndex: 3},
+			&model.UndefinedVariableError{Var: "z", Line: 4, Column: 7},
+		),
+		&model.CycleError{Path: []string{"a", "b", "a"}},
+		&model.CycleError{Path: []string{"c", "c"}, Line: 3, Column: 6},
+		&model.ReassignmentError{Var: "w", FirstCommandIndex: 1, CommandIndex: 4, FirstLine: 2, Line: 5, Column: 6},
+		&model.SyntaxError{Line: 6, Column: 12, Code: model.CodeUnknownOperation, Message: `unknown operation "^"`},
+		&model.SyntaxError{Line: 7, Column: 1, Message: "expected calc or print"},
+		&model.CalculationError{Var: "q", CommandIndex: 5, Err: model.ErrDivisionByZero},
+		fmt.Errorf("wrapped: %w", &model.FieldError{CommandIndex: 1, Field: "op", Code: model.CodeUnknownOperation,
+			Err: errors.New("unknown operation")}),
+		errors.New("boom"),
+	)
+
+	details := model.ErrorDetails(err)
+
+	assert.Equal(t, []model.ErrorDetail{
+		{Code: model.CodeReassignment, Message: `command 2: variable "x" is already assigned by command 0`,
+			CommandIndex: 2, Var: "x"},
+		{Code: model.CodeUndefinedVariable, Message: `command 3: variable "y" is never assigned`,
+			CommandIndex: 3, Var: "y"},
+		{Code: model.CodeUndefinedVariable, Message: `line 4, column 7: variable "z" is never assigned`,
+			CommandIndex: model.NoCommandIndex, Var: "z", Line: 4, Column: 7},
+		{Code: model.CodeDependencyCycle, Message: "dependency cycle: a -> b -> a",
+			CommandIndex: model.NoCommandIndex, Var: "a"},
+		{Code: model.CodeDependencyCycle, Message: "line 3, column 6: dependency cycle: c -> c",
+			CommandIndex: model.NoCommandIndex, Var: "c", Line: 3, Column: 6},
+		{Code: model.CodeReassignment, Message: `line 5, column 6: variable "w" is already assigned on line 2`,
+			CommandIndex: model.NoCommandIndex, Var: "w", Line: 5, Column: 6},
+		{Code: model.CodeUnknownOperation, Message: `line 6, column 12: unknown operation "^"`,
+			CommandIndex: model.NoCommandIndex, Line: 6, Column: 12},
+		{Code: model.CodeSyntaxError, Message: "line 7, column 1: expected calc or print",
+			CommandIndex: model.NoCommandIndex, Line: 7, Column: 1},
+		{Code: model.CodeDivisionByZero, Message: `command 5: cannot calculate "q": division by zero`,
+			CommandIndex: 5, Var: "q"},
+		{Code: model.CodeUnknownOperation, Message: `wrapped: command 1: field "op": unknown operation`,
+			CommandIndex: 1, Field: "op"},
+		{Code: model.CodeInternal, Message: "boom", CommandIndex: model.NoCommandIndex},
+	}, details)
+
+	assert.Nil(t, model.ErrorDetails(nil))
+}
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidProgram  = errors.New("invalid program")
+	ErrDivisionByZero  = errors.New("division by zero")
+	ErrOverflow        = errors.New("integer overflow")
+	ErrFractionalValue = errors.New("fractional value outside of decimal mode")
+	ErrTimeout         = errors.New("calculation timed out")
+	ErrCancelled       = errors.New("calculation cancelled")
+)
+
+func CancellationCause(ctxErr error) error {
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+
+	return ErrCancelled
+}
+
+type UndefinedVariableError struct {
+	Var          string
+	CommandIndex int
+	Line         int
+	Column       int
+}
+
+func (e *UndefinedVariableError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d, column %d: variable %q is never assigned", e.Line, e.Column, e.Var)
+	}
+
+	return fmt.Sprintf("command %d: variable %q is never assigned", e.CommandIndex, e.Var)
+}
+
+func (e *UndefinedVariableError) Is(target error) bool {
+	return target == ErrInvalidProgram
+}
+
+type CycleError struct {
+	Path   []string
+	Line   int
+	Column int
+}
+
+func (e *CycleError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d, column %d: dependency cycle: %s", e.Line, e.Column, strings.Join(e.Path, " -> "))
+	}
+
+	return fmt.Sprintf("dependency cycle: %s", strings.Join(e.Path, " -> "))
+}
+
+func (e *CycleError) Is(target error) bool {
+	return target == ErrInvalidProgram
+}
+
+type ReassignmentError struct {
+	Var               string
+	FirstCommandIndex int
+	CommandIndex      int
+	FirstLine         int
+	Line              int
+	Column            int
+}
+
+func (e *ReassignmentError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d, column %d: variable %q is already assigned on line %d",
+			e.Line, e.Column, e.Var, e.FirstLine)
+	}
+
+	return fmt.Sprintf("command %d: variable %q is already assigned by command %d",
+		e.CommandIndex, e.Var, e.FirstCommandIndex)
+}
+
+func (e *ReassignmentError) Is(target error) bool {
+	return target == ErrInvalidProgram
+}
+
+type CalculationError struct {
+	Var          string
+	CommandIndex int
+	Line         int
+	Column       int
+	Err          error
+}
+
+func (e *CalculationError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d, column %d: cannot calculate %q: %v", e.Line, e.Column, e.Var, e.Err)
+	}
+
+	return fmt.Sprintf("command %d: cannot calculate %q: %v", e.CommandIndex, e.Var, e.Err)
+}
+
+func (e *CalculationError) Unwrap() error {
+	return e.Err
+}
+
+type SyntaxError struct {
+	Line    int
+	Column  int
+	Code    ErrorCode
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+func (e *SyntaxError) Is(target error) bool {
+	return target == ErrInvalidProgram
+}
+
+type FieldError struct {
+	CommandIndex int
+	Field        string
+	Code         ErrorCode
+	Err          error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("command %d: field %q: %v", e.CommandIndex, e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+func (e *FieldError) Is(target error) bool {
+	return target == ErrInvalidProgram
+}
+
+type OptionError struct {
+	Option string
+	Value  string
+}
+
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("invalid value %q for option %q", e.Value, e.Option)
+}
+
+func (e *OptionError) Is(target error) bool {
+	return target == ErrInvalidProgram
+}
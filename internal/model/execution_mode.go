@@ -0,0 +1,17 @@
+package model
+
+const (
+	ModeConcurrent ExecutionMode = "concurrent"
+	ModeSequential ExecutionMode = "sequential"
+)
+
+type ExecutionMode string
+
+func IsValidExecutionMode(mode ExecutionMode) bool {
+	switch mode {
+	case ModeConcurrent, ModeSequential:
+		return true
+	default:
+		return false
+	}
+}
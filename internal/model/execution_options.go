@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type ExecutionOptions struct {
+	Overflow     OverflowMode
+	Numeric      NumericMode
+	Scale        int32
+	Timeout      time.Duration
+	MaxTimeout   time.Duration
+	MaxValueBits int
+	Mode         ExecutionMode
+	Progress     *Progress
+	Resolved     func(variable *Variable)
+}
+
+func DefaultExecutionOptions() ExecutionOptions {
+	return ExecutionOptions{Overflow: OverflowError, Numeric: NumericInt64, Scale: DefaultDecimalScale}
+}
+
+func IsValidDecimalScale(scale int32) bool {
+	return scale >= 0 && scale <= MaxDecimalScale
+}
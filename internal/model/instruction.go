@@ -0,0 +1,40 @@
+package model
+
+const (
+	OperandName OperandKind = iota + 1
+	OperandNumber
+	OperandLiteral
+	OperandUnsupported
+)
+
+type OperandKind uint8
+
+type Operand struct {
+	Kind OperandKind
+	Text string
+}
+
+func NameOperand(name string) Operand {
+	return Operand{Kind: OperandName, Text: name}
+}
+
+func NumberOperand(literal string) Operand {
+	return Operand{Kind: OperandNumber, Text: literal}
+}
+
+func LiteralOperand(literal string) Operand {
+	return Operand{Kind: OperandLiteral, Text: literal}
+}
+
+func UnsupportedOperand() Operand {
+	return Operand{Kind: OperandUnsupported}
+}
+
+type Instruction struct {
+	Type     CommandType
+	Var      string
+	Op       string
+	Rounding RoundingMode
+	Left     Operand
+	Right    Operand
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	JobRunning   JobStatus = "running"
+	JobDone      JobStatus = "done"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrTooManyJobs = errors.New("too many running jobs")
+)
+
+type JobStatus string
+
+type Job struct {
+	ID         string
+	Status     JobStatus
+	Options    ExecutionOptions
+	Resolved   int
+	Required   int
+	Results    []*Variable
+	Err        error
+	CreatedAt  time.Time
+	FinishedAt time.Time
+}
+
+func (j Job) IsFinished() bool {
+	return j.Status != JobRunning
+}
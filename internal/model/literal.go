@@ -0,0 +1,41 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+func ParseNumericLiteral(literal string, mode NumericMode) (Argument, error) {
+	if !IsDecimalLiteral(literal) {
+		return nil, fmt.Errorf("invalid number %s", literal)
+	}
+
+	if strings.Contains(literal, ".") {
+		if mode != NumericDecimal {
+			return nil, fmt.Errorf("fractional literal %s requires decimal numeric mode", literal)
+		}
+
+		decimal, err := ParseDecimal(literal)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewDecimalArgument(decimal), nil
+	}
+
+	integer, ok := new(big.Int).SetString(literal, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %s", literal)
+	}
+
+	if integer.IsInt64() {
+		return NumericArgument(integer.Int64()), nil
+	}
+
+	if mode == NumericInt64 {
+		return nil, fmt.Errorf("%s is out of int64 range", literal)
+	}
+
+	return NewDecimalArgument(Decimal{Unscaled: integer}), nil
+}
@@ -1,9 +1,19 @@
 package model
 
+import "math/big"
+
 type NumericArgument int64
 
-func (n NumericArgument) GetValue() int64 {
-	return int64(n)
+func (n NumericArgument) GetValue() (int64, error) {
+	return int64(n), nil
+}
+
+func (n NumericArgument) GetBigValue() (*big.Int, error) {
+	return big.NewInt(int64(n)), nil
+}
+
+func (n NumericArgument) GetDecimalValue() (Decimal, error) {
+	return Decimal{Unscaled: big.NewInt(int64(n))}, nil
 }
 
 func (n NumericArgument) HasDependency() bool {
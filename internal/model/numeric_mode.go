@@ -0,0 +1,18 @@
+package model
+
+const (
+	NumericInt64   NumericMode = "int64"
+	NumericBig     NumericMode = "big"
+	NumericDecimal NumericMode = "decimal"
+)
+
+type NumericMode string
+
+func IsValidNumericMode(mode NumericMode) bool {
+	switch mode {
+	case NumericInt64, NumericBig, NumericDecimal:
+		return true
+	default:
+		return false
+	}
+}
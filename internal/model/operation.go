@@ -1,16 +1,18 @@
 package model
 
 const (
-	Plus = iota
+	Plus Operation = iota
 	Minus
 	Multiply
+	Divide
+	Modulo
 )
 
 type Operation uint8
 
 func IsValidOperationBySymbol(symbol string) bool {
 	switch symbol {
-	case "+", "-", "*":
+	case "+", "-", "*", "/", "%":
 		return true
 	default:
 		return false
@@ -25,7 +27,28 @@ func GetOperationBySymbol(symbol string) Operation {
 		return Minus
 	case "*":
 		return Multiply
+	case "/":
+		return Divide
+	case "%":
+		return Modulo
 	}
 
 	return 0
 }
+
+func (o Operation) Symbol() string {
+	switch o {
+	case Plus:
+		return "+"
+	case Minus:
+		return "-"
+	case Multiply:
+		return "*"
+	case Divide:
+		return "/"
+	case Modulo:
+		return "%"
+	}
+
+	return ""
+}
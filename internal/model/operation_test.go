@@ -32,8 +32,20 @@ func TestOperations(t *testing.T) {
 			expectedOp:    model.Multiply,
 		},
 		{
-			name:          "Invalid operation",
+			name:          "Divide operation",
 			symbol:        "/",
+			expectedValid: true,
+			expectedOp:    model.Divide,
+		},
+		{
+			name:          "Modulo operation",
+			symbol:        "%",
+			expectedValid: true,
+			expectedOp:    model.Modulo,
+		},
+		{
+			name:          "Invalid operation",
+			symbol:        "^",
 			expectedValid: false,
 		},
 		{
@@ -54,6 +66,10 @@ func TestOperations(t *testing.T) {
 
 			op := model.GetOperationBySymbol(tt.symbol)
 			assert.Equal(t, tt.expectedOp, op)
+
+			if tt.expectedValid {
+				assert.Equal(t, tt.symbol, op.Symbol())
+			}
 		})
 	}
 }
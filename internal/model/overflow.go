@@ -0,0 +1,18 @@
+package model
+
+const (
+	OverflowError    OverflowMode = "error"
+	OverflowWrap     OverflowMode = "wrap"
+	OverflowSaturate OverflowMode = "saturate"
+)
+
+type OverflowMode string
+
+func IsValidOverflowMode(mode OverflowMode) bool {
+	switch mode {
+	case OverflowError, OverflowWrap, OverflowSaturate:
+		return true
+	default:
+		return false
+	}
+}
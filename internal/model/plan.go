@@ -0,0 +1,14 @@
+package model
+
+type PlannedCommand struct {
+	CommandIndex int
+	Var          string
+}
+
+type Plan struct {
+	Commands     []Command
+	Required     []PlannedCommand
+	Levels       [][]PlannedCommand
+	CriticalPath []PlannedCommand
+	Dead         []PlannedCommand
+}
@@ -0,0 +1,27 @@
+package model
+
+import "sync/atomic"
+
+type Progress struct {
+	resolved atomic.Int64
+	required atomic.Int64
+}
+
+func NewProgress() *Progress {
+	return &Progress{}
+}
+
+func (p *Progress) Track(required int) {
+	p.required.Store(int64(required))
+}
+
+func (p *Progress) Resolve() {
+	p.resolved.Add(1)
+}
+
+// Counts never reports more resolved variables than required: a calc that
+// finishes while the deadline cancels it is counted twice.
+func (p *Progress) Counts() (resolved, required int) {
+	required = int(p.required.Load())
+	return min(int(p.resolved.Load()), required), required
+}
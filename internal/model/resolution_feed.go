@@ -0,0 +1,48 @@
+package model
+
+import "sync"
+
+type ResolutionFeed struct {
+	mu        sync.Mutex
+	positions map[*Variable][]int
+	indexes   chan int
+}
+
+func NewResolutionFeed(targets []*Variable) *ResolutionFeed {
+	positions := make(map[*Variable][]int, len(targets))
+	for i, target := range targets {
+		positions[target] = append(positions[target], i)
+	}
+
+	return &ResolutionFeed{positions: positions, indexes: make(chan int, len(targets))}
+}
+
+func (f *ResolutionFeed) Notify(variable *Variable) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, i := range f.positions[variable] {
+		f.indexes <- i
+	}
+
+	delete(f.positions, variable)
+}
+
+func (f *ResolutionFeed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for variable, positions := range f.positions {
+		<-variable.Done()
+		for _, i := range positions {
+			f.indexes <- i
+		}
+	}
+
+	f.positions = nil
+	close(f.indexes)
+}
+
+func (f *ResolutionFeed) Indexes() <-chan int {
+	return f.indexes
+}
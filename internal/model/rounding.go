@@ -0,0 +1,21 @@
+package model
+
+const (
+	Trunc    RoundingMode = "trunc"
+	Floor    RoundingMode = "floor"
+	Ceil     RoundingMode = "ceil"
+	Euclid   RoundingMode = "euclid"
+	HalfUp   RoundingMode = "half_up"
+	HalfEven RoundingMode = "half_even"
+)
+
+type RoundingMode string
+
+func IsValidRoundingMode(mode RoundingMode) bool {
+	switch mode {
+	case Trunc, Floor, Ceil, Euclid, HalfUp, HalfEven:
+		return true
+	default:
+		return false
+	}
+}
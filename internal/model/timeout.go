@@ -0,0 +1,17 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+const DefaultMaxTimeout = 10 * time.Second
+
+func ParseTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout: %q", value)
+	}
+
+	return timeout, nil
+}
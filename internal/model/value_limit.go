@@ -0,0 +1,15 @@
+package model
+
+import "math/big"
+
+const DefaultMaxValueBits = 1 << 16
+
+func ExceedsValueBits(maxBits int, values ...*big.Int) bool {
+	for _, value := range values {
+		if value.BitLen() > maxBits {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,9 +1,19 @@
 package model
 
+import (
+	"errors"
+	"math/big"
+	"sync"
+)
+
 type Variable struct {
-	name  string
-	value int64
-	done  chan struct{}
+	name     string
+	value    int64
+	bigValue *big.Int
+	decimal  Decimal
+	err      error
+	once     sync.Once
+	done     chan struct{}
 }
 
 func NewVariable(name string) *Variable {
@@ -11,14 +21,91 @@ func NewVariable(name string) *Variable {
 }
 
 func (v *Variable) SetValue(value int64) {
-	v.value = value
-	close(v.done)
+	v.resolve(func() { v.value = value })
+}
+
+func (v *Variable) SetBigValue(value *big.Int) {
+	v.resolve(func() { v.bigValue = value })
+}
+
+func (v *Variable) SetDecimalValue(value Decimal) {
+	v.resolve(func() { v.decimal = value })
+}
+
+func (v *Variable) SetError(err error) {
+	v.resolve(func() { v.err = err })
+}
+
+func (v *Variable) resolve(set func()) {
+	v.once.Do(func() {
+		set()
+		close(v.done)
+	})
+}
+
+func (v *Variable) IsResolved() bool {
+	select {
+	case <-v.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (v *Variable) Done() <-chan struct{} {
+	return v.done
+}
+
+func (v *Variable) Status() VariableStatus {
+	<-v.done
+
+	switch {
+	case v.err == nil:
+		return StatusOK
+	case errors.Is(v.err, ErrTimeout):
+		return StatusTimeout
+	case errors.Is(v.err, ErrCancelled):
+		return StatusCancelled
+	default:
+		return StatusError
+	}
 }
 
-func (v *Variable) GetValue() int64 {
+func (v *Variable) GetValue() (int64, error) {
 	select {
 	case <-v.done:
-		return v.value
+		return v.value, v.err
+	}
+}
+
+func (v *Variable) GetBigValue() (*big.Int, error) {
+	select {
+	case <-v.done:
+		if v.err != nil {
+			return nil, v.err
+		}
+
+		if v.bigValue == nil {
+			return big.NewInt(v.value), nil
+		}
+
+		return v.bigValue, nil
+	}
+}
+
+func (v *Variable) GetDecimalValue() (Decimal, error) {
+	select {
+	case <-v.done:
+		if v.err != nil {
+			return Decimal{}, v.err
+		}
+
+		if v.decimal.Unscaled != nil {
+			return v.decimal, nil
+		}
+
+		value, _ := v.GetBigValue()
+		return Decimal{Unscaled: value}, nil
 	}
 }
 
@@ -0,0 +1,10 @@
+package model
+
+const (
+	StatusOK        VariableStatus = "ok"
+	StatusError     VariableStatus = "error"
+	StatusTimeout   VariableStatus = "timeout"
+	StatusCancelled VariableStatus = "cancelled"
+)
+
+type VariableStatus string
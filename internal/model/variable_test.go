@@ -0,0 +1,69 @@
+package model_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/model"
+	"testing"
+)
+
+func TestVariableStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolve  func(v *model.Variable)
+		expected model.VariableStatus
+	}{
+		{name: "value", resolve: func(v *model.Variable) { v.SetValue(1) }, expected: model.StatusOK},
+		{name: "error", resolve: func(v *model.Variable) { v.SetError(model.ErrDivisionByZero) }, expected: model.StatusError},
+		{
+			name: "timeout",
+			resolve: func(v *model.Variable) {
+				v.SetError(&model.CalculationError{Var: "x", Err: model.ErrTimeout})
+			},
+			expected: model.StatusTimeout,
+		},
+		{
+			name: "cancelled",
+			resolve: func(v *model.Variable) {
+				v.SetError(&model.CalculationError{Var: "x", Err: model.ErrCancelled})
+			},
+			expected: model.StatusCancelled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := model.NewVariable("x")
+			assert.False(t, v.IsResolved())
+
+			tt.resolve(v)
+			v.SetValue(2)
+
+			assert.True(t, v.IsResolved())
+			assert.Equal(t, tt.expected, v.Status())
+		})
+	}
+}
+
+func TestResolutionFeed(t *testing.T) {
+	variables := []*model.Variable{model.NewVariable("a"), model.NewVariable("b"), model.NewVariable("c")}
+	variables = append(variables, variables[0])
+	feed := model.NewResolutionFeed(variables)
+
+	variables[2].SetValue(2)
+	feed.Notify(variables[2])
+	assert.Equal(t, 2, <-feed.Indexes())
+
+	variables[0].SetValue(0)
+	feed.Notify(variables[0])
+	feed.Notify(variables[0])
+	feed.Notify(model.NewVariable("unrelated"))
+	assert.Equal(t, 0, <-feed.Indexes())
+	assert.Equal(t, 3, <-feed.Indexes())
+
+	variables[1].SetValue(1)
+	feed.Close()
+	assert.Equal(t, 1, <-feed.Indexes())
+
+	_, ok := <-feed.Indexes()
+	assert.False(t, ok)
+}
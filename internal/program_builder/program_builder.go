@@ -0,0 +1,191 @@
+package program_builder
+
+import (
+	"errors"
+	"fmt"
+	"industrial-calculator/internal/model"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const maxLiteralExponent = 1000
+
+type programPlanner interface {
+	PlanInstructions(commands []model.Command) (*model.Plan, error)
+}
+
+type builder struct {
+	planner programPlanner
+}
+
+func NewBuilder(planner programPlanner) *builder {
+	return &builder{planner: planner}
+}
+
+// Build reports every field error together with the reassignment, undefined
+// variable and cycle errors found in the commands that did build.
+func (b *builder) Build(instructions []model.Instruction, options model.ExecutionOptions) ([]model.Command, error) {
+	commands := make([]model.Command, len(instructions))
+	vars := make(map[string]*model.Variable)
+
+	var errs []error
+	for i, instruction := range instructions {
+		command, err := b.BuildCommand(i, instruction, vars, options)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		commands[i] = command
+	}
+
+	if len(errs) > 0 {
+		_, err := b.planner.PlanInstructions(commands)
+		return nil, errors.Join(append(errs, err)...)
+	}
+
+	return commands, nil
+}
+
+func (b *builder) BuildCommand(index int, instruction model.Instruction, vars map[string]*model.Variable,
+	options model.ExecutionOptions,
+) (model.Command, error) {
+	variable := func(name string) *model.Variable {
+		if _, ok := vars[name]; !ok {
+			vars[name] = model.NewVariable(name)
+		}
+
+		return vars[name]
+	}
+
+	var errs []error
+	fieldError := func(field string, code model.ErrorCode, err error) {
+		errs = append(errs, &model.FieldError{CommandIndex: index, Field: field, Code: code, Err: err})
+	}
+
+	if !model.IsValidCommand(instruction.Type) {
+		fieldError("type", model.CodeUnknownCommandType, fmt.Errorf("unknown command type %q", instruction.Type))
+		return model.Command{}, errors.Join(errs...)
+	}
+
+	if instruction.Type == model.Print {
+		return model.Command{Type: model.Print, Var: variable(instruction.Var)}, nil
+	}
+
+	if !model.IsValidOperationBySymbol(instruction.Op) {
+		fieldError("op", model.CodeUnknownOperation, fmt.Errorf("unknown operation %q", instruction.Op))
+	}
+
+	rounding := model.Trunc
+	if instruction.Rounding != "" {
+		rounding = instruction.Rounding
+	}
+
+	if !model.IsValidRoundingMode(rounding) {
+		fieldError("rounding", model.CodeUnknownRoundingMode, fmt.Errorf("unknown rounding mode %q", instruction.Rounding))
+	}
+
+	left, code, err := buildOperand(instruction.Left, variable, options)
+	if err != nil {
+		fieldError("left", code, err)
+	}
+
+	right, code, err := buildOperand(instruction.Right, variable, options)
+	if err != nil {
+		fieldError("right", code, err)
+	}
+
+	// a command with field errors still assigns its variable, so the
+	// remaining checks do not report its readers as undefined
+	if left == nil {
+		left = model.NumericArgument(0)
+	}
+
+	if right == nil {
+		right = model.NumericArgument(0)
+	}
+
+	return model.Command{
+		Type:     model.Calc,
+		Var:      variable(instruction.Var),
+		Op:       model.GetOperationBySymbol(instruction.Op),
+		Rounding: rounding,
+		Left:     left,
+		Right:    right,
+	}, errors.Join(errs...)
+}
+
+func buildOperand(operand model.Operand, variable func(name string) *model.Variable,
+	options model.ExecutionOptions,
+) (model.Argument, model.ErrorCode, error) {
+	switch operand.Kind {
+	case model.OperandName:
+		if options.Numeric == model.NumericDecimal && model.IsDecimalLiteral(operand.Text) {
+			return parseLiteral(operand.Text, options)
+		}
+
+		return variable(operand.Text), "", nil
+	case model.OperandNumber:
+		return parseLiteral(operand.Text, options)
+	case model.OperandLiteral:
+		if !model.IsDecimalLiteral(operand.Text) {
+			return nil, model.CodeInvalidLiteral, fmt.Errorf("%s is not a valid number", operand.Text)
+		}
+
+		return parseLiteral(operand.Text, options)
+	case model.OperandUnsupported:
+		return nil, model.CodeInvalidOperand, errors.New("operand must be a number or a variable name")
+	default:
+		return nil, model.CodeMissingOperand, errors.New("operand is required")
+	}
+}
+
+func parseLiteral(literal string, options model.ExecutionOptions) (model.Argument, model.ErrorCode, error) {
+	argument, err := parseNumber(literal, options)
+	if err != nil {
+		return nil, model.CodeInvalidLiteral, err
+	}
+
+	return argument, "", nil
+}
+
+func parseNumber(literal string, options model.ExecutionOptions) (model.Argument, error) {
+	if options.Numeric == model.NumericDecimal && model.IsDecimalLiteral(literal) && strings.Contains(literal, ".") {
+		decimal, err := model.ParseDecimal(literal)
+		if err != nil {
+			return nil, err
+		}
+
+		return model.NewDecimalArgument(decimal), nil
+	}
+
+	if _, exponent, ok := strings.Cut(strings.ToLower(literal), "e"); ok {
+		if value, err := strconv.Atoi(exponent); err != nil || value > maxLiteralExponent || value < -maxLiteralExponent {
+			return nil, fmt.Errorf("%s has too large exponent", literal)
+		}
+	}
+
+	rat, ok := new(big.Rat).SetString(literal)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a valid number", literal)
+	}
+
+	if !rat.IsInt() && options.Numeric == model.NumericDecimal {
+		return nil, fmt.Errorf("%s must be written in plain decimal notation", literal)
+	}
+
+	if !rat.IsInt() {
+		return nil, fmt.Errorf("%s is not an integer", literal)
+	}
+
+	integer := rat.Num()
+	if integer.IsInt64() {
+		return model.NumericArgument(integer.Int64()), nil
+	}
+
+	if options.Numeric == model.NumericInt64 {
+		return nil, fmt.Errorf("%s is out of int64 range", literal)
+	}
+
+	return model.NewDecimalArgument(model.Decimal{Unscaled: integer}), nil
+}
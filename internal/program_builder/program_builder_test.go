@@ -0,0 +1,199 @@
+package program_builder_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_builder"
+	"testing"
+)
+
+func TestBuildResolvesForwardReferences(t *testing.T) {
+	instructions := []model.Instruction{
+		{Type: model.Calc, Var: "x", Op: "+", Left: model.NameOperand("y"), Right: model.NumberOperand("1")},
+		{Type: model.Calc, Var: "y", Op: "/", Rounding: model.Floor,
+			Left: model.NumberOperand("-7"), Right: model.NumberOperand("2")},
+		{Type: model.Print, Var: "x"},
+	}
+
+	commands, err := program_builder.NewBuilder(noPlanner{}).Build(instructions, model.DefaultExecutionOptions())
+	assert.NoError(t, err)
+	assert.Len(t, commands, 3)
+
+	assert.Same(t, commands[1].Var, commands[0].Left)
+	assert.Same(t, commands[0].Var, commands[2].Var)
+	assert.Equal(t, model.Trunc, commands[0].Rounding)
+	assert.Equal(t, model.Divide, commands[1].Op)
+	assert.Equal(t, model.Floor, commands[1].Rounding)
+	assert.Equal(t, model.NumericArgument(-7), commands[1].Left)
+}
+
+func TestBuildDecimalNamesAreLiterals(t *testing.T) {
+	options := model.DefaultExecutionOptions()
+	options.Numeric = model.NumericDecimal
+
+	instructions := []model.Instruction{
+		{Type: model.Calc, Var: "x", Op: "*", Left: model.NameOperand("1.2500"), Right: model.NumberOperand("0.5")},
+	}
+
+	commands, err := program_builder.NewBuilder(noPlanner{}).Build(instructions, options)
+	assert.NoError(t, err)
+
+	left, err := commands[0].Left.GetDecimalValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2500", left.String())
+	assert.False(t, commands[0].Left.HasDependency())
+}
+
+func TestBuildErrors(t *testing.T) {
+	valid := model.Instruction{Type: model.Calc, Var: "x", Op: "+",
+		Left: model.NumberOperand("1"), Right: model.NumberOperand("2")}
+
+	with := func(change func(instruction *model.Instruction)) model.Instruction {
+		instruction := valid
+		change(&instruction)
+		return instruction
+	}
+
+	tests := []struct {
+		name        string
+		instruction model.Instruction
+		field       string
+		expected    string
+	}{
+		{
+			name:        "unknown command type",
+			instruction: with(func(i *model.Instruction) { i.Type = "show" }),
+			field:       "type",
+			expected:    `command 1: field "type": unknown command type "show"`,
+		},
+		{
+			name:        "unknown operation",
+			instruction: with(func(i *model.Instruction) { i.Op = "^" }),
+			field:       "op",
+			expected:    `command 1: field "op": unknown operation "^"`,
+		},
+		{
+			name:        "unknown rounding mode",
+			instruction: with(func(i *model.Instruction) { i.Rounding = "nearest" }),
+			field:       "rounding",
+			expected:    `command 1: field "rounding": unknown rounding mode "nearest"`,
+		},
+		{
+			name:        "missing operand",
+			instruction: with(func(i *model.Instruction) { i.Left = model.Operand{} }),
+			field:       "left",
+			expected:    `command 1: field "left": operand is required`,
+		},
+		{
+			name:        "fractional literal",
+			instruction: with(func(i *model.Instruction) { i.Right = model.NumberOperand("2.5") }),
+			field:       "right",
+			expected:    `command 1: field "right": 2.5 is not an integer`,
+		},
+		{
+			name:        "literal beyond int64",
+			instruction: with(func(i *model.Instruction) { i.Right = model.NumberOperand("9223372036854775808") }),
+			field:       "right",
+			expected:    `command 1: field "right": 9223372036854775808 is out of int64 range`,
+		},
+		{
+			name:        "text literal with fraction syntax",
+			instruction: with(func(i *model.Instruction) { i.Right = model.LiteralOperand("4/2") }),
+			field:       "right",
+			expected:    `command 1: field "right": 4/2 is not a valid number`,
+		},
+		{
+			name:        "text literal with base prefix",
+			instruction: with(func(i *model.Instruction) { i.Right = model.LiteralOperand("0x10") }),
+			field:       "right",
+			expected:    `command 1: field "right": 0x10 is not a valid number`,
+		},
+		{
+			name:        "text literal with digit separators",
+			instruction: with(func(i *model.Instruction) { i.Right = model.LiteralOperand("1_000") }),
+			field:       "right",
+			expected:    `command 1: field "right": 1_000 is not a valid number`,
+		},
+		{
+			name:        "text literal with exponent",
+			instruction: with(func(i *model.Instruction) { i.Right = model.LiteralOperand("1e3") }),
+			field:       "right",
+			expected:    `command 1: field "right": 1e3 is not a valid number`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instructions := []model.Instruction{{Type: model.Print, Var: "x"}, tt.instruction}
+
+			_, err := program_builder.NewBuilder(noPlanner{}).Build(instructions, model.DefaultExecutionOptions())
+
+			assert.EqualError(t, err, tt.expected)
+			assert.ErrorIs(t, err, model.ErrInvalidProgram)
+
+			var fieldErr *model.FieldError
+			assert.True(t, errors.As(err, &fieldErr))
+			assert.Equal(t, 1, fieldErr.CommandIndex)
+			assert.Equal(t, tt.field, fieldErr.Field)
+		})
+	}
+}
+
+func TestBuildLiteralArgumentTypes(t *testing.T) {
+	for _, numeric := range []model.NumericMode{model.NumericInt64, model.NumericBig, model.NumericDecimal} {
+		t.Run(string(numeric), func(t *testing.T) {
+			options := model.DefaultExecutionOptions()
+			options.Numeric = numeric
+
+			commands, err := program_builder.NewBuilder(noPlanner{}).Build([]model.Instruction{
+				{Type: model.Calc, Var: "x", Op: "+", Left: model.NumberOperand("12"), Right: model.LiteralOperand("12")},
+			}, options)
+			require.NoError(t, err)
+
+			assert.Equal(t, model.NumericArgument(12), commands[0].Left)
+			assert.Equal(t, model.NumericArgument(12), commands[0].Right)
+		})
+	}
+}
+
+func TestBuildChecksCommandsThatBuilt(t *testing.T) {
+	planner := &recordingPlanner{err: &model.UndefinedVariableError{Var: "q", CommandIndex: 1}}
+	instructions := []model.Instruction{
+		{Type: model.Calc, Var: "x", Op: "^", Left: model.NumberOperand("1"), Right: model.NumberOperand("2")},
+		{Type: model.Calc, Var: "y", Op: "+", Left: model.NameOperand("q"), Right: model.NumberOperand("2")},
+		{Type: model.Print, Var: "x"},
+	}
+
+	_, err := program_builder.NewBuilder(planner).Build(instructions, model.DefaultExecutionOptions())
+
+	var fieldErr *model.FieldError
+	require.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "op", fieldErr.Field)
+
+	var undefined *model.UndefinedVariableError
+	require.True(t, errors.As(err, &undefined))
+	assert.Equal(t, "q", undefined.Var)
+
+	require.Len(t, planner.commands, 3)
+	assert.Equal(t, "x", planner.commands[0].Var.GetName())
+	assert.Equal(t, "y", planner.commands[1].Var.GetName())
+	assert.True(t, planner.commands[2].IsPrint())
+}
+
+type noPlanner struct{}
+
+func (noPlanner) PlanInstructions(commands []model.Command) (*model.Plan, error) {
+	return &model.Plan{}, nil
+}
+
+type recordingPlanner struct {
+	commands []model.Command
+	err      error
+}
+
+func (p *recordingPlanner) PlanInstructions(commands []model.Command) (*model.Plan, error) {
+	p.commands = commands
+	return nil, p.err
+}
@@ -0,0 +1,50 @@
+package program_validator
+
+import (
+	"errors"
+	"industrial-calculator/internal/model"
+)
+
+type validator struct {
+}
+
+func NewValidator() *validator {
+	return &validator{}
+}
+
+func (v *validator) Validate(commands []model.Command) error {
+	assigned := make(map[*model.Variable]struct{})
+	for i := range commands {
+		if commands[i].IsCalc() {
+			assigned[commands[i].Var] = struct{}{}
+		}
+	}
+
+	var errs []error
+	check := func(argument model.Argument, index int) {
+		if !argument.HasDependency() {
+			return
+		}
+
+		variable, ok := argument.(*model.Variable)
+		if !ok {
+			return
+		}
+
+		if _, ok := assigned[variable]; !ok {
+			errs = append(errs, &model.UndefinedVariableError{Var: variable.GetName(), CommandIndex: index})
+		}
+	}
+
+	for i, cmd := range commands {
+		switch {
+		case cmd.IsPrint():
+			check(cmd.Var, i)
+		case cmd.IsCalc():
+			check(cmd.Left, i)
+			check(cmd.Right, i)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,90 @@
+package program_validator_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_validator"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	v := program_validator.NewValidator()
+
+	vars := map[string]*model.Variable{
+		"x": model.NewVariable("x"),
+		"y": model.NewVariable("y"),
+		"z": model.NewVariable("z"),
+	}
+
+	testCases := []struct {
+		name     string
+		commands []model.Command
+		expected []model.UndefinedVariableError
+	}{
+		{
+			name:     "empty program",
+			commands: []model.Command{},
+		},
+		{
+			name: "all variables assigned",
+			commands: []model.Command{
+				{Type: model.Calc, Var: vars["x"], Op: model.Plus, Left: model.NumericArgument(1), Right: model.NumericArgument(2)},
+				{Type: model.Calc, Var: vars["y"], Op: model.Multiply, Left: vars["x"], Right: vars["x"]},
+				{Type: model.Print, Var: vars["y"]},
+			},
+		},
+		{
+			name: "forward reference is allowed",
+			commands: []model.Command{
+				{Type: model.Calc, Var: vars["y"], Op: model.Plus, Left: vars["x"], Right: model.NumericArgument(1)},
+				{Type: model.Calc, Var: vars["x"], Op: model.Plus, Left: model.NumericArgument(1), Right: model.NumericArgument(2)},
+				{Type: model.Print, Var: vars["y"]},
+			},
+		},
+		{
+			name: "print of unassigned variable",
+			commands: []model.Command{
+				{Type: model.Print, Var: vars["x"]},
+			},
+			expected: []model.UndefinedVariableError{{Var: "x", CommandIndex: 0}},
+		},
+		{
+			name: "calc operands reference unassigned variables",
+			commands: []model.Command{
+				{Type: model.Calc, Var: vars["x"], Op: model.Plus, Left: model.NumericArgument(1), Right: model.NumericArgument(2)},
+				{Type: model.Calc, Var: vars["y"], Op: model.Minus, Left: vars["z"], Right: vars["x"]},
+				{Type: model.Print, Var: vars["y"]},
+				{Type: model.Print, Var: vars["z"]},
+			},
+			expected: []model.UndefinedVariableError{
+				{Var: "z", CommandIndex: 1},
+				{Var: "z", CommandIndex: 3},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.Validate(tc.commands)
+
+			if len(tc.expected) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			joined, ok := err.(interface{ Unwrap() []error })
+			assert.True(t, ok)
+
+			var actual []model.UndefinedVariableError
+			for _, e := range joined.Unwrap() {
+				var undefinedErr *model.UndefinedVariableError
+				if errors.As(e, &undefinedErr) {
+					actual = append(actual, *undefinedErr)
+				}
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
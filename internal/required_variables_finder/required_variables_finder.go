@@ -10,14 +10,20 @@ func NewFinder() *finder {
 }
 
 func (f *finder) FindRequiredVariables(calcCommandByVariable map[*model.Variable]model.Command, targets []*model.Variable,
-) map[*model.Variable]struct{} {
+) (map[*model.Variable]struct{}, error) {
 	required := make(map[*model.Variable]struct{})
 	visited := make(map[*model.Variable]struct{})
+	onPath := make(map[*model.Variable]int)
+	path := make([]*model.Variable, 0)
+
+	var dfs func(argument *model.Variable) error
+	dfs = func(argument *model.Variable) error {
+		if start, ok := onPath[argument]; ok {
+			return f.buildCycleError(path[start:], argument, calcCommandByVariable[path[start]])
+		}
 
-	var dfs func(argument *model.Variable)
-	dfs = func(argument *model.Variable) {
 		if _, ok := visited[argument]; ok {
-			return
+			return nil
 		}
 
 		visited[argument] = struct{}{}
@@ -25,21 +31,45 @@ func (f *finder) FindRequiredVariables(calcCommandByVariable map[*model.Variable
 		if cmd, ok := calcCommandByVariable[argument]; ok {
 			required[argument] = struct{}{}
 
+			onPath[argument] = len(path)
+			path = append(path, argument)
+
 			if cmd.Left.HasDependency() {
-				dfs(f.mustGetVariableByArgument(cmd.Left))
+				if err := dfs(f.mustGetVariableByArgument(cmd.Left)); err != nil {
+					return err
+				}
 			}
 
 			if cmd.Right.HasDependency() {
-				dfs(f.mustGetVariableByArgument(cmd.Right))
+				if err := dfs(f.mustGetVariableByArgument(cmd.Right)); err != nil {
+					return err
+				}
 			}
+
+			path = path[:len(path)-1]
+			delete(onPath, argument)
 		}
+
+		return nil
 	}
 
 	for _, target := range targets {
-		dfs(target)
+		if err := dfs(target); err != nil {
+			return nil, err
+		}
+	}
+
+	return required, nil
+}
+
+func (f *finder) buildCycleError(cycle []*model.Variable, closing *model.Variable, first model.Command,
+) *model.CycleError {
+	names := make([]string, 0, len(cycle)+1)
+	for _, v := range cycle {
+		names = append(names, v.GetName())
 	}
 
-	return required
+	return &model.CycleError{Path: append(names, closing.GetName()), Line: first.Line, Column: first.Column}
 }
 
 func (f *finder) mustGetVariableByArgument(argument model.Argument) *model.Variable {
@@ -24,6 +24,7 @@ func TestFindRequiredVariables(t *testing.T) {
 		commands map[*model.Variable]model.Command
 		targets  []*model.Variable
 		expected []string
+		cycle    []string
 	}{
 		{
 			name:     "empty input",
@@ -119,14 +120,108 @@ func TestFindRequiredVariables(t *testing.T) {
 					Right: model.NumericArgument(2),
 				},
 			},
-			targets:  []*model.Variable{vars["a"]},
-			expected: []string{"a", "b"},
+			targets: []*model.Variable{vars["a"]},
+			cycle:   []string{"a", "b", "a"},
+		},
+		{
+			name: "self reference",
+			commands: map[*model.Variable]model.Command{
+				vars["x"]: {
+					Type:  model.Calc,
+					Var:   vars["x"],
+					Op:    model.Plus,
+					Left:  vars["x"],
+					Right: model.NumericArgument(1),
+				},
+			},
+			targets: []*model.Variable{vars["x"]},
+			cycle:   []string{"x", "x"},
+		},
+		{
+			name: "cycle below acyclic prefix",
+			commands: map[*model.Variable]model.Command{
+				vars["x"]: {
+					Type:  model.Calc,
+					Var:   vars["x"],
+					Op:    model.Plus,
+					Left:  vars["a"],
+					Right: model.NumericArgument(1),
+				},
+				vars["a"]: {
+					Type:  model.Calc,
+					Var:   vars["a"],
+					Op:    model.Plus,
+					Left:  model.NumericArgument(1),
+					Right: vars["b"],
+				},
+				vars["b"]: {
+					Type:  model.Calc,
+					Var:   vars["b"],
+					Op:    model.Minus,
+					Left:  vars["c"],
+					Right: model.NumericArgument(2),
+				},
+				vars["c"]: {
+					Type:  model.Calc,
+					Var:   vars["c"],
+					Op:    model.Multiply,
+					Left:  vars["a"],
+					Right: model.NumericArgument(2),
+				},
+			},
+			targets: []*model.Variable{vars["x"]},
+			cycle:   []string{"a", "b", "c", "a"},
+		},
+		{
+			name: "diamond is not a cycle",
+			commands: map[*model.Variable]model.Command{
+				vars["a"]: {
+					Type:  model.Calc,
+					Var:   vars["a"],
+					Op:    model.Plus,
+					Left:  model.NumericArgument(1),
+					Right: model.NumericArgument(2),
+				},
+				vars["b"]: {
+					Type:  model.Calc,
+					Var:   vars["b"],
+					Op:    model.Plus,
+					Left:  vars["a"],
+					Right: model.NumericArgument(1),
+				},
+				vars["c"]: {
+					Type:  model.Calc,
+					Var:   vars["c"],
+					Op:    model.Plus,
+					Left:  vars["a"],
+					Right: model.NumericArgument(2),
+				},
+				vars["x"]: {
+					Type:  model.Calc,
+					Var:   vars["x"],
+					Op:    model.Plus,
+					Left:  vars["b"],
+					Right: vars["c"],
+				},
+			},
+			targets:  []*model.Variable{vars["x"]},
+			expected: []string{"a", "b", "c", "x"},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := f.FindRequiredVariables(tc.commands, tc.targets)
+			result, err := f.FindRequiredVariables(tc.commands, tc.targets)
+
+			if tc.cycle != nil {
+				var cycleErr *model.CycleError
+				assert.ErrorAs(t, err, &cycleErr)
+				assert.Equal(t, tc.cycle, cycleErr.Path)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
 
 			var resultNames []string
 			for v := range result {
@@ -0,0 +1,184 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/sentence"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+type Task struct {
+	Index   int
+	Command model.Command
+}
+
+func ParsePoolSize(value string) (int, error) {
+	if value == "" {
+		return runtime.GOMAXPROCS(0), nil
+	}
+
+	size, err := strconv.Atoi(value)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("invalid worker pool size: %q", value)
+	}
+
+	return size, nil
+}
+
+type poolScheduler struct {
+	workers int
+}
+
+func NewPoolScheduler(workers int) *poolScheduler {
+	return &poolScheduler{workers: workers}
+}
+
+func (s *poolScheduler) Run(ctx context.Context, tasks map[*model.Variable]Task, options model.ExecutionOptions) {
+	if len(tasks) == 0 {
+		return
+	}
+
+	pending := make(map[*model.Variable]int, len(tasks))
+	dependents := make(map[*model.Variable][]*model.Variable, len(tasks))
+	queue := make(chan *model.Variable, len(tasks))
+	completed := make(chan *model.Variable, len(tasks))
+
+	for variable, task := range tasks {
+		for _, argument := range []model.Argument{task.Command.Left, task.Command.Right} {
+			dependency, ok := argument.(*model.Variable)
+			if !ok {
+				continue
+			}
+
+			if _, ok := tasks[dependency]; !ok {
+				continue
+			}
+
+			pending[variable]++
+			dependents[dependency] = append(dependents[dependency], variable)
+		}
+
+		if pending[variable] == 0 {
+			queue <- variable
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(s.workers, len(tasks)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for variable := range queue {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				task := tasks[variable]
+				sentence.NewSentence(task.Index, task.Command, options).Evaluate()
+				completed <- variable
+			}
+		}()
+	}
+
+	defer wg.Wait()
+	defer close(queue)
+
+	for remaining := len(tasks); remaining > 0; remaining-- {
+		select {
+		case <-ctx.Done():
+			cancelUnresolved(ctx, tasks, options)
+			return
+		case variable := <-completed:
+			for _, dependent := range dependents[variable] {
+				pending[dependent]--
+				if pending[dependent] == 0 {
+					queue <- dependent
+				}
+			}
+		}
+	}
+}
+
+func cancelUnresolved(ctx context.Context, tasks map[*model.Variable]Task, options model.ExecutionOptions) {
+	for variable, task := range tasks {
+		if !variable.IsResolved() {
+			sentence.NewSentence(task.Index, task.Command, options).Cancel(ctx.Err())
+		}
+	}
+}
+
+type channelScheduler struct {
+}
+
+func NewChannelScheduler() *channelScheduler {
+	return &channelScheduler{}
+}
+
+func (s *channelScheduler) Run(ctx context.Context, tasks map[*model.Variable]Task, options model.ExecutionOptions) {
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		sentence := sentence.NewSentence(task.Index, task.Command, options)
+
+		wg.Add(1)
+		go func() {
+			sentence.Calc(ctx)
+			wg.Done()
+		}()
+	}
+
+	wg.Wait()
+}
+
+type sequentialScheduler struct {
+}
+
+func NewSequentialScheduler() *sequentialScheduler {
+	return &sequentialScheduler{}
+}
+
+func (s *sequentialScheduler) Run(ctx context.Context, tasks map[*model.Variable]Task, options model.ExecutionOptions) {
+	order := make([]*model.Variable, 0, len(tasks))
+	for variable := range tasks {
+		order = append(order, variable)
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		return tasks[order[i]].Index < tasks[order[j]].Index
+	})
+
+	visited := make(map[*model.Variable]struct{}, len(tasks))
+
+	var visit func(variable *model.Variable)
+	visit = func(variable *model.Variable) {
+		if _, ok := visited[variable]; ok {
+			return
+		}
+
+		visited[variable] = struct{}{}
+
+		task := tasks[variable]
+		for _, argument := range []model.Argument{task.Command.Left, task.Command.Right} {
+			if dependency, ok := argument.(*model.Variable); ok {
+				if _, ok := tasks[dependency]; ok {
+					visit(dependency)
+				}
+			}
+		}
+
+		evaluated := sentence.NewSentence(task.Index, task.Command, options)
+		if ctx.Err() != nil {
+			evaluated.Cancel(ctx.Err())
+			return
+		}
+
+		evaluated.Evaluate()
+	}
+
+	for _, variable := range order {
+		visit(variable)
+	}
+}
@@ -0,0 +1,248 @@
+package scheduler_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/scheduler"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type calcScheduler interface {
+	Run(ctx context.Context, tasks map[*model.Variable]scheduler.Task, options model.ExecutionOptions)
+}
+
+func schedulers() map[string]calcScheduler {
+	return map[string]calcScheduler{
+		"pool of one":  scheduler.NewPoolScheduler(1),
+		"pool of four": scheduler.NewPoolScheduler(4),
+		"channels":     scheduler.NewChannelScheduler(),
+		"sequential":   scheduler.NewSequentialScheduler(),
+	}
+}
+
+func addTask(tasks map[*model.Variable]scheduler.Task, name string, op model.Operation, left, right model.Argument,
+) *model.Variable {
+	variable := model.NewVariable(name)
+	tasks[variable] = scheduler.Task{
+		Index:   len(tasks),
+		Command: model.Command{Type: model.Calc, Var: variable, Op: op, Left: left, Right: right},
+	}
+
+	return variable
+}
+
+func TestParsePoolSize(t *testing.T) {
+	size, err := scheduler.ParsePoolSize("")
+	assert.NoError(t, err)
+	assert.Positive(t, size)
+
+	size, err = scheduler.ParsePoolSize("16")
+	assert.NoError(t, err)
+	assert.Equal(t, 16, size)
+
+	_, err = scheduler.ParsePoolSize("0")
+	assert.Error(t, err)
+
+	_, err = scheduler.ParsePoolSize("many")
+	assert.Error(t, err)
+}
+
+func TestRunDiamond(t *testing.T) {
+	for name, s := range schedulers() {
+		t.Run(name, func(t *testing.T) {
+			tasks := make(map[*model.Variable]scheduler.Task)
+			a := addTask(tasks, "a", model.Plus, model.NumericArgument(2), model.NumericArgument(3))
+			b := addTask(tasks, "b", model.Multiply, a, model.NumericArgument(2))
+			c := addTask(tasks, "c", model.Minus, a, model.NumericArgument(1))
+			d := addTask(tasks, "d", model.Multiply, b, c)
+			e := addTask(tasks, "e", model.Plus, d, d)
+
+			s.Run(context.Background(), tasks, model.DefaultExecutionOptions())
+
+			value, err := e.GetValue()
+			assert.NoError(t, err)
+			assert.Equal(t, int64(80), value)
+		})
+	}
+}
+
+func TestRunPropagatesErrors(t *testing.T) {
+	for name, s := range schedulers() {
+		t.Run(name, func(t *testing.T) {
+			tasks := make(map[*model.Variable]scheduler.Task)
+			a := addTask(tasks, "a", model.Divide, model.NumericArgument(1), model.NumericArgument(0))
+			b := addTask(tasks, "b", model.Plus, a, model.NumericArgument(1))
+			c := addTask(tasks, "c", model.Plus, model.NumericArgument(1), model.NumericArgument(1))
+
+			s.Run(context.Background(), tasks, model.DefaultExecutionOptions())
+
+			_, err := b.GetValue()
+			assert.ErrorIs(t, err, model.ErrDivisionByZero)
+
+			value, err := c.GetValue()
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), value)
+		})
+	}
+}
+
+func TestRunEmpty(t *testing.T) {
+	for name, s := range schedulers() {
+		t.Run(name, func(t *testing.T) {
+			s.Run(context.Background(), map[*model.Variable]scheduler.Task{}, model.DefaultExecutionOptions())
+		})
+	}
+}
+
+func wideGraph(size int) (map[*model.Variable]scheduler.Task, *model.Variable) {
+	tasks := make(map[*model.Variable]scheduler.Task, size)
+	leaves := make([]*model.Variable, size/2)
+	for i := range leaves {
+		leaves[i] = addTask(tasks, "l"+strconv.Itoa(i), model.Plus, model.NumericArgument(i), model.NumericArgument(1))
+	}
+
+	var sum model.Argument = model.NumericArgument(0)
+	for i, leaf := range leaves {
+		sum = addTask(tasks, "s"+strconv.Itoa(i), model.Plus, sum, leaf)
+	}
+
+	return tasks, sum.(*model.Variable)
+}
+
+func deepGraph(size int) (map[*model.Variable]scheduler.Task, *model.Variable) {
+	tasks := make(map[*model.Variable]scheduler.Task, size)
+
+	var last model.Argument = model.NumericArgument(0)
+	for i := 0; i < size; i++ {
+		last = addTask(tasks, "x"+strconv.Itoa(i), model.Plus, last, model.NumericArgument(1))
+	}
+
+	return tasks, last.(*model.Variable)
+}
+
+func TestRunLargeGraphs(t *testing.T) {
+	for name, s := range schedulers() {
+		t.Run(name, func(t *testing.T) {
+			tasks, result := wideGraph(2000)
+			s.Run(context.Background(), tasks, model.DefaultExecutionOptions())
+
+			value, err := result.GetValue()
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1000*1001/2), value)
+
+			tasks, result = deepGraph(2000)
+			s.Run(context.Background(), tasks, model.DefaultExecutionOptions())
+
+			value, err = result.GetValue()
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2000), value)
+		})
+	}
+}
+
+func assertNoLeakedGoroutines(t *testing.T, before int) {
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+}
+
+func TestRunCancellation(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	contexts := []struct {
+		name     string
+		ctx      context.Context
+		expected model.VariableStatus
+		cause    error
+	}{
+		{name: "cancelled", ctx: cancelled, expected: model.StatusCancelled, cause: model.ErrCancelled},
+		{name: "deadline exceeded", ctx: expired, expected: model.StatusTimeout, cause: model.ErrTimeout},
+	}
+
+	for name, s := range schedulers() {
+		for _, c := range contexts {
+			t.Run(name+"/"+c.name, func(t *testing.T) {
+				before := runtime.NumGoroutine()
+				tasks, result := deepGraph(5000)
+
+				s.Run(c.ctx, tasks, model.DefaultExecutionOptions())
+
+				for variable := range tasks {
+					assert.True(t, variable.IsResolved())
+				}
+
+				assert.Equal(t, c.expected, result.Status())
+				_, err := result.GetValue()
+				assert.ErrorIs(t, err, c.cause)
+				assert.ErrorContains(t, err, `cannot calculate "x4999"`)
+
+				assertNoLeakedGoroutines(t, before)
+			})
+		}
+	}
+}
+
+func TestRunTimeoutKeepsPartialResults(t *testing.T) {
+	for name, s := range schedulers() {
+		t.Run(name, func(t *testing.T) {
+			before := runtime.NumGoroutine()
+			tasks, result := deepGraph(100000)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+			defer cancel()
+
+			s.Run(ctx, tasks, model.DefaultExecutionOptions())
+
+			statuses := make(map[model.VariableStatus]int)
+			for variable := range tasks {
+				statuses[variable.Status()]++
+			}
+
+			assert.Equal(t, model.StatusTimeout, result.Status())
+			assert.Equal(t, len(tasks), statuses[model.StatusOK]+statuses[model.StatusTimeout])
+
+			assertNoLeakedGoroutines(t, before)
+		})
+	}
+}
+
+func benchmarkRun(b *testing.B, s calcScheduler, graph func(size int) (map[*model.Variable]scheduler.Task, *model.Variable)) {
+	for _, size := range []int{1000, 100000} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				tasks, _ := graph(size)
+				b.StartTimer()
+
+				s.Run(context.Background(), tasks, model.DefaultExecutionOptions())
+			}
+		})
+	}
+}
+
+func BenchmarkPoolSchedulerWide(b *testing.B) {
+	benchmarkRun(b, scheduler.NewPoolScheduler(8), wideGraph)
+}
+
+func BenchmarkChannelSchedulerWide(b *testing.B) {
+	benchmarkRun(b, scheduler.NewChannelScheduler(), wideGraph)
+}
+
+func BenchmarkPoolSchedulerDeep(b *testing.B) {
+	benchmarkRun(b, scheduler.NewPoolScheduler(8), deepGraph)
+}
+
+func BenchmarkChannelSchedulerDeep(b *testing.B) {
+	benchmarkRun(b, scheduler.NewChannelScheduler(), deepGraph)
+}
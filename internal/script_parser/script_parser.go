@@ -0,0 +1,251 @@
+package script_parser
+
+import (
+	"errors"
+	"fmt"
+	"industrial-calculator/internal/model"
+	"strings"
+	"unicode"
+)
+
+type field struct {
+	text   string
+	column int
+}
+
+type reference struct {
+	name         string
+	commandIndex int
+	line         int
+	column       int
+}
+
+type parser struct {
+}
+
+func NewParser() *parser {
+	return &parser{}
+}
+
+func (p *parser) Parse(source string, options model.ExecutionOptions) ([]model.Command, error) {
+	s := &state{
+		options:  options,
+		vars:     make(map[string]*model.Variable),
+		assigned: make(map[string]struct{}),
+		commands: make([]model.Command, 0),
+	}
+
+	var errs []error
+	for i, line := range strings.Split(source, "\n") {
+		if err := s.parseLine(i+1, line); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, ref := range s.references {
+		if _, ok := s.assigned[ref.name]; !ok {
+			errs = append(errs, &model.UndefinedVariableError{
+				Var:          ref.name,
+				CommandIndex: ref.commandIndex,
+				Line:         ref.line,
+				Column:       ref.column,
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return s.commands, nil
+}
+
+type state struct {
+	options    model.ExecutionOptions
+	vars       map[string]*model.Variable
+	assigned   map[string]struct{}
+	references []reference
+	commands   []model.Command
+}
+
+func (s *state) parseLine(line int, text string) error {
+	fields := split(text)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	errorAt := func(f field, code model.ErrorCode, format string, args ...interface{}) error {
+		return &model.SyntaxError{Line: line, Column: f.column, Code: code, Message: fmt.Sprintf(format, args...)}
+	}
+
+	keyword := fields[0]
+	endOfLine := field{column: len([]rune(text)) + 1}
+
+	switch keyword.text {
+	case string(model.Print):
+		if len(fields) < 2 {
+			return errorAt(endOfLine, model.CodeSyntaxError, "expected variable name after print")
+		}
+
+		if len(fields) > 2 {
+			return errorAt(fields[2], model.CodeSyntaxError, "unexpected %q after print statement", fields[2].text)
+		}
+
+		if !isIdentifier(fields[1].text) {
+			return errorAt(fields[1], model.CodeSyntaxError, "invalid variable name %q", fields[1].text)
+		}
+
+		s.reference(fields[1], line)
+		s.commands = append(s.commands, model.Command{
+			Type:   model.Print,
+			Var:    s.variable(fields[1].text),
+			Line:   line,
+			Column: fields[1].column,
+		})
+		return nil
+	case string(model.Calc):
+		return s.parseCalc(fields, endOfLine, errorAt, line)
+	default:
+		return errorAt(keyword, model.CodeUnknownCommandType, "unknown command %q, expected calc or print", keyword.text)
+	}
+}
+
+func (s *state) parseCalc(fields []field, endOfLine field,
+	errorAt func(f field, code model.ErrorCode, format string, args ...interface{}) error, line int,
+) error {
+	expected := []string{"variable name", "\"=\"", "left operand", "operation", "right operand"}
+
+	if len(fields) > 1 && !isIdentifier(fields[1].text) {
+		return errorAt(fields[1], model.CodeSyntaxError, "invalid variable name %q", fields[1].text)
+	}
+
+	if len(fields) > 1 {
+		s.assigned[fields[1].text] = struct{}{}
+	}
+
+	if len(fields) > 2 && fields[2].text != "=" {
+		return errorAt(fields[2], model.CodeSyntaxError, "expected \"=\", got %q", fields[2].text)
+	}
+
+	if len(fields) > 4 && !model.IsValidOperationBySymbol(fields[4].text) {
+		return errorAt(fields[4], model.CodeUnknownOperation, "unknown operation %q", fields[4].text)
+	}
+
+	if len(fields) < len(expected)+1 {
+		code := model.CodeSyntaxError
+		if len(fields) > 3 {
+			code = model.CodeMissingOperand
+		}
+
+		return errorAt(endOfLine, code, "expected %s", expected[len(fields)-1])
+	}
+
+	if len(fields) > len(expected)+2 {
+		return errorAt(fields[len(expected)+2], model.CodeSyntaxError, "unexpected %q after calc statement", fields[len(expected)+2].text)
+	}
+
+	target, leftField, opField, rightField := fields[1], fields[3], fields[4], fields[5]
+
+	rounding := model.Trunc
+	if len(fields) == len(expected)+2 {
+		rounding = model.RoundingMode(fields[6].text)
+		if !model.IsValidRoundingMode(rounding) {
+			return errorAt(fields[6], model.CodeUnknownRoundingMode, "unknown rounding mode %q", fields[6].text)
+		}
+	}
+
+	left, code, err := s.operand(leftField, line)
+	if err != nil {
+		return errorAt(leftField, code, "%v", err)
+	}
+
+	right, code, err := s.operand(rightField, line)
+	if err != nil {
+		return errorAt(rightField, code, "%v", err)
+	}
+
+	s.commands = append(s.commands, model.Command{
+		Type:     model.Calc,
+		Var:      s.variable(target.text),
+		Op:       model.GetOperationBySymbol(opField.text),
+		Rounding: rounding,
+		Left:     left,
+		Right:    right,
+		Line:     line,
+		Column:   target.column,
+	})
+
+	return nil
+}
+
+func (s *state) operand(f field, line int) (model.Argument, model.ErrorCode, error) {
+	if isIdentifier(f.text) {
+		s.reference(f, line)
+		return s.variable(f.text), "", nil
+	}
+
+	first := []rune(f.text)[0]
+	if !unicode.IsDigit(first) && first != '-' && first != '+' {
+		return nil, model.CodeInvalidOperand, fmt.Errorf("invalid operand %q", f.text)
+	}
+
+	argument, err := model.ParseNumericLiteral(f.text, s.options.Numeric)
+	if err != nil {
+		return nil, model.CodeInvalidLiteral, err
+	}
+
+	return argument, "", nil
+}
+
+func (s *state) reference(f field, line int) {
+	s.references = append(s.references, reference{
+		name:         f.text,
+		commandIndex: len(s.commands),
+		line:         line,
+		column:       f.column,
+	})
+}
+
+func (s *state) variable(name string) *model.Variable {
+	if _, ok := s.vars[name]; !ok {
+		s.vars[name] = model.NewVariable(name)
+	}
+
+	return s.vars[name]
+}
+
+func split(line string) []field {
+	fields := make([]field, 0)
+	runes := []rune(line)
+
+	for i := 0; i < len(runes); {
+		if runes[i] == '#' {
+			break
+		}
+
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(runes) && !unicode.IsSpace(runes[j]) && runes[j] != '#' {
+			j++
+		}
+
+		fields = append(fields, field{text: string(runes[i:j]), column: i + 1})
+		i = j
+	}
+
+	return fields
+}
+
+func isIdentifier(text string) bool {
+	for i, r := range text {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+
+	return text != ""
+}
@@ -0,0 +1,136 @@
+package script_parser_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/script_parser"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	program := "# totals\n" +
+		"calc x = y * 2   # doubled\n" +
+		"\n" +
+		"calc y = 7 / 2 half_up\n" +
+		"print x\n"
+
+	commands, err := script_parser.NewParser().Parse(program, model.DefaultExecutionOptions())
+	assert.NoError(t, err)
+	assert.Len(t, commands, 3)
+
+	assert.Equal(t, model.Calc, commands[0].Type)
+	assert.Equal(t, "x", commands[0].Var.GetName())
+	assert.Equal(t, model.Multiply, commands[0].Op)
+	assert.Equal(t, model.Trunc, commands[0].Rounding)
+	assert.Same(t, commands[1].Var, commands[0].Left)
+	assert.Equal(t, model.NumericArgument(2), commands[0].Right)
+
+	assert.Equal(t, model.Divide, commands[1].Op)
+	assert.Equal(t, model.HalfUp, commands[1].Rounding)
+
+	assert.Equal(t, model.Print, commands[2].Type)
+	assert.Same(t, commands[0].Var, commands[2].Var)
+
+	for i, position := range [][2]int{{2, 6}, {4, 6}, {5, 7}} {
+		assert.Equal(t, position, [2]int{commands[i].Line, commands[i].Column})
+	}
+}
+
+func TestParseLiteralsFollowNumericMode(t *testing.T) {
+	options := model.DefaultExecutionOptions()
+	options.Numeric = model.NumericDecimal
+
+	commands, err := script_parser.NewParser().Parse("calc x = 1.25 + -3", options)
+	assert.NoError(t, err)
+
+	left, err := commands[0].Left.GetDecimalValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.25", left.String())
+
+	right, err := commands[0].Right.GetDecimalValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "-3", right.String())
+}
+
+func TestParseSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		program  string
+		expected model.SyntaxError
+	}{
+		{
+			name:     "unknown command",
+			program:  "calc x = 1 + 2\nshow x",
+			expected: model.SyntaxError{Line: 2, Column: 1, Code: model.CodeUnknownCommandType, Message: `unknown command "show", expected calc or print`},
+		},
+		{
+			name:     "unknown operation",
+			program:  "calc x = 2 ^ 3",
+			expected: model.SyntaxError{Line: 1, Column: 12, Code: model.CodeUnknownOperation, Message: `unknown operation "^"`},
+		},
+		{
+			name:     "missing assignment",
+			program:  "calc x 1 + 2",
+			expected: model.SyntaxError{Line: 1, Column: 8, Code: model.CodeSyntaxError, Message: `expected "=", got "1"`},
+		},
+		{
+			name:     "truncated statement",
+			program:  "calc x = 1 +",
+			expected: model.SyntaxError{Line: 1, Column: 13, Code: model.CodeMissingOperand, Message: "expected right operand"},
+		},
+		{
+			name:     "fraction outside decimal mode",
+			program:  "calc x = 1.5 + 1",
+			expected: model.SyntaxError{Line: 1, Column: 10, Code: model.CodeInvalidLiteral, Message: "fractional literal 1.5 requires decimal numeric mode"},
+		},
+		{
+			name:     "literal beyond int64",
+			program:  "calc x = 1 + 9223372036854775808",
+			expected: model.SyntaxError{Line: 1, Column: 14, Code: model.CodeInvalidLiteral, Message: "9223372036854775808 is out of int64 range"},
+		},
+		{
+			name:     "invalid operand",
+			program:  "calc x = 1 + $y",
+			expected: model.SyntaxError{Line: 1, Column: 14, Code: model.CodeInvalidOperand, Message: `invalid operand "$y"`},
+		},
+		{
+			name:     "unknown rounding mode",
+			program:  "calc x = 1 / 2 nearest",
+			expected: model.SyntaxError{Line: 1, Column: 16, Code: model.CodeUnknownRoundingMode, Message: `unknown rounding mode "nearest"`},
+		},
+		{
+			name:     "trailing field after print",
+			program:  "print x y",
+			expected: model.SyntaxError{Line: 1, Column: 9, Code: model.CodeSyntaxError, Message: `unexpected "y" after print statement`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := script_parser.NewParser().Parse(tt.program, model.DefaultExecutionOptions())
+
+			var syntaxErr *model.SyntaxError
+			assert.True(t, errors.As(err, &syntaxErr))
+			assert.Equal(t, tt.expected, *syntaxErr)
+			assert.ErrorIs(t, err, model.ErrInvalidProgram)
+		})
+	}
+}
+
+func TestParseReportsAllErrors(t *testing.T) {
+	program := "calc x = y + 1\n" +
+		"calc z = 1 ^ 2\n" +
+		"  print w\n"
+
+	_, err := script_parser.NewParser().Parse(program, model.DefaultExecutionOptions())
+
+	assert.ErrorIs(t, err, model.ErrInvalidProgram)
+	assert.ErrorContains(t, err, `line 2, column 12: unknown operation "^"`)
+	assert.ErrorContains(t, err, `line 1, column 10: variable "y" is never assigned`)
+	assert.ErrorContains(t, err, `line 3, column 9: variable "w" is never assigned`)
+
+	var undefinedErr *model.UndefinedVariableError
+	assert.True(t, errors.As(err, &undefinedErr))
+	assert.Equal(t, model.UndefinedVariableError{Var: "y", CommandIndex: 0, Line: 1, Column: 10}, *undefinedErr)
+}
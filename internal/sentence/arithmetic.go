@@ -0,0 +1,110 @@
+package sentence
+
+import (
+	"industrial-calculator/internal/model"
+	"math"
+)
+
+func calcTwoValuesByOperation(a, b int64, op model.Operation, rounding model.RoundingMode, overflow model.OverflowMode,
+) (int64, error) {
+	var value int64
+	var overflowed bool
+	var saturated int64
+
+	switch op {
+	case model.Plus:
+		value = a + b
+		overflowed = (a > 0 && b > 0 && value < 0) || (a < 0 && b < 0 && value >= 0)
+		saturated = saturate(a > 0)
+	case model.Minus:
+		value = a - b
+		overflowed = (a >= 0 && b < 0 && value < 0) || (a < 0 && b > 0 && value >= 0)
+		saturated = saturate(a >= 0)
+	case model.Multiply:
+		value = a * b
+		overflowed = a != 0 && (value/a != b || (a == -1 && b == math.MinInt64))
+		saturated = saturate((a < 0) == (b < 0))
+	case model.Divide:
+		quotient, _, err := divide(a, b, rounding)
+		if err != nil {
+			return 0, err
+		}
+
+		value = quotient
+		overflowed = a == math.MinInt64 && b == -1
+		saturated = math.MaxInt64
+	case model.Modulo:
+		_, remainder, err := divide(a, b, rounding)
+		return remainder, err
+	}
+
+	if !overflowed {
+		return value, nil
+	}
+
+	switch overflow {
+	case model.OverflowWrap:
+		return value, nil
+	case model.OverflowSaturate:
+		return saturated, nil
+	default:
+		return 0, model.ErrOverflow
+	}
+}
+
+func saturate(positive bool) int64 {
+	if positive {
+		return math.MaxInt64
+	}
+
+	return math.MinInt64
+}
+
+func divide(a, b int64, rounding model.RoundingMode) (int64, int64, error) {
+	if b == 0 {
+		return 0, 0, model.ErrDivisionByZero
+	}
+
+	quotient, remainder := a/b, a%b
+	if remainder == 0 {
+		return quotient, remainder, nil
+	}
+
+	switch rounding {
+	case model.Floor:
+		if (remainder < 0) != (b < 0) {
+			quotient, remainder = quotient-1, remainder+b
+		}
+	case model.Ceil:
+		if (remainder < 0) == (b < 0) {
+			quotient, remainder = quotient+1, remainder-b
+		}
+	case model.Euclid:
+		if remainder < 0 && b > 0 {
+			quotient, remainder = quotient-1, remainder+b
+		} else if remainder < 0 {
+			quotient, remainder = quotient+1, remainder-b
+		}
+	case model.HalfUp, model.HalfEven:
+		absRemainder, absDivisor := absUint64(remainder), absUint64(b)
+		rest := absDivisor - absRemainder
+		tie := absRemainder == rest
+		if absRemainder > rest || (tie && (rounding == model.HalfUp || quotient%2 != 0)) {
+			if (remainder < 0) == (b < 0) {
+				quotient, remainder = quotient+1, remainder-b
+			} else {
+				quotient, remainder = quotient-1, remainder+b
+			}
+		}
+	}
+
+	return quotient, remainder, nil
+}
+
+func absUint64(value int64) uint64 {
+	if value < 0 {
+		return uint64(-(value + 1)) + 1
+	}
+
+	return uint64(value)
+}
@@ -0,0 +1,104 @@
+package sentence
+
+import (
+	"industrial-calculator/internal/model"
+	"math/big"
+)
+
+func calcTwoBigValuesByOperation(a, b *big.Int, op model.Operation, rounding model.RoundingMode, maxBits int,
+) (*big.Int, error) {
+	if err := checkOperandBits(a, b, op, maxBits); err != nil {
+		return nil, err
+	}
+
+	var value *big.Int
+	switch op {
+	case model.Plus:
+		value = new(big.Int).Add(a, b)
+	case model.Minus:
+		value = new(big.Int).Sub(a, b)
+	case model.Multiply:
+		value = new(big.Int).Mul(a, b)
+	case model.Divide:
+		quotient, _, err := divideBig(a, b, rounding)
+		if err != nil {
+			return nil, err
+		}
+
+		value = quotient
+	case model.Modulo:
+		_, remainder, err := divideBig(a, b, rounding)
+		if err != nil {
+			return nil, err
+		}
+
+		value = remainder
+	default:
+		value = new(big.Int)
+	}
+
+	if model.ExceedsValueBits(maxBits, value) {
+		return nil, model.ErrOverflow
+	}
+
+	return value, nil
+}
+
+func checkOperandBits(a, b *big.Int, op model.Operation, maxBits int) error {
+	if model.ExceedsValueBits(maxBits, a, b) {
+		return model.ErrOverflow
+	}
+
+	if op == model.Multiply && a.BitLen()+b.BitLen()-1 > maxBits {
+		return model.ErrOverflow
+	}
+
+	return nil
+}
+
+func divideBig(a, b *big.Int, rounding model.RoundingMode) (*big.Int, *big.Int, error) {
+	if b.Sign() == 0 {
+		return nil, nil, model.ErrDivisionByZero
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(a, b, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient, remainder, nil
+	}
+
+	one := big.NewInt(1)
+	switch rounding {
+	case model.Floor:
+		if remainder.Sign() != b.Sign() {
+			quotient.Sub(quotient, one)
+			remainder.Add(remainder, b)
+		}
+	case model.Ceil:
+		if remainder.Sign() == b.Sign() {
+			quotient.Add(quotient, one)
+			remainder.Sub(remainder, b)
+		}
+	case model.Euclid:
+		if remainder.Sign() < 0 && b.Sign() > 0 {
+			quotient.Sub(quotient, one)
+			remainder.Add(remainder, b)
+		} else if remainder.Sign() < 0 {
+			quotient.Add(quotient, one)
+			remainder.Sub(remainder, b)
+		}
+	case model.HalfUp, model.HalfEven:
+		rest := new(big.Int).Sub(new(big.Int).Abs(b), new(big.Int).Abs(remainder))
+		cmp := new(big.Int).Abs(remainder).Cmp(rest)
+		if cmp > 0 || (cmp == 0 && (rounding == model.HalfUp || quotient.Bit(0) != 0)) {
+			if remainder.Sign() == b.Sign() {
+				quotient.Add(quotient, one)
+				remainder.Sub(remainder, b)
+			} else {
+				quotient.Sub(quotient, one)
+				remainder.Add(remainder, b)
+			}
+		}
+	}
+
+	return quotient, remainder, nil
+}
@@ -0,0 +1,70 @@
+package sentence
+
+import (
+	"industrial-calculator/internal/model"
+	"math/big"
+)
+
+func calcTwoDecimalValuesByOperation(a, b model.Decimal, op model.Operation, rounding model.RoundingMode, scale int32,
+	maxBits int,
+) (model.Decimal, error) {
+	if model.ExceedsValueBits(maxBits, a.Unscaled, b.Unscaled) {
+		return model.Decimal{}, model.ErrOverflow
+	}
+
+	left := rescale(a, scale, rounding).Unscaled
+	right := rescale(b, scale, rounding).Unscaled
+	if err := checkOperandBits(left, right, op, maxBits+pow10(scale).BitLen()); err != nil {
+		return model.Decimal{}, err
+	}
+
+	var unscaled *big.Int
+	switch op {
+	case model.Plus:
+		unscaled = new(big.Int).Add(left, right)
+	case model.Minus:
+		unscaled = new(big.Int).Sub(left, right)
+	case model.Multiply:
+		product := model.Decimal{Unscaled: new(big.Int).Mul(left, right), Scale: 2 * scale}
+		unscaled = rescale(product, scale, rounding).Unscaled
+	case model.Divide:
+		quotient, _, err := divideBig(new(big.Int).Mul(left, pow10(scale)), right, rounding)
+		if err != nil {
+			return model.Decimal{}, err
+		}
+
+		unscaled = quotient
+	case model.Modulo:
+		_, remainder, err := divideBig(left, right, rounding)
+		if err != nil {
+			return model.Decimal{}, err
+		}
+
+		unscaled = remainder
+	default:
+		unscaled = new(big.Int)
+	}
+
+	if model.ExceedsValueBits(maxBits, unscaled) {
+		return model.Decimal{}, model.ErrOverflow
+	}
+
+	return model.Decimal{Unscaled: unscaled, Scale: scale}, nil
+}
+
+func rescale(value model.Decimal, scale int32, rounding model.RoundingMode) model.Decimal {
+	switch {
+	case value.Scale == scale:
+		return value
+	case value.Scale < scale:
+		unscaled := new(big.Int).Mul(value.Unscaled, pow10(scale-value.Scale))
+		return model.Decimal{Unscaled: unscaled, Scale: scale}
+	default:
+		unscaled, _, _ := divideBig(value.Unscaled, pow10(value.Scale-scale), rounding)
+		return model.Decimal{Unscaled: unscaled, Scale: scale}
+	}
+}
+
+func pow10(exponent int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
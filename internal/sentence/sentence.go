@@ -3,45 +3,183 @@ package sentence
 import (
 	"context"
 	"industrial-calculator/internal/model"
+	"math/big"
 )
 
 type Sentence struct {
-	vr    *model.Variable
-	op    model.Operation
-	left  model.Argument
-	right model.Argument
+	index     int
+	vr        *model.Variable
+	op        model.Operation
+	rounding  model.RoundingMode
+	left      model.Argument
+	right     model.Argument
+	line      int
+	column    int
+	statement string
+	options   model.ExecutionOptions
 }
 
-func NewSentence(vr *model.Variable, op model.Operation, left model.Argument, right model.Argument) *Sentence {
-	return &Sentence{vr: vr, op: op, left: left, right: right}
+func NewSentence(index int, cmd model.Command, options model.ExecutionOptions) *Sentence {
+	return &Sentence{
+		index:     index,
+		vr:        cmd.Var,
+		op:        cmd.Op,
+		rounding:  cmd.Rounding,
+		left:      cmd.Left,
+		right:     cmd.Right,
+		line:      cmd.Line,
+		column:    cmd.Column,
+		statement: cmd.Statement,
+		options:   options,
+	}
 }
 
 func (s *Sentence) Calc(ctx context.Context) {
-	done := make(chan struct{})
-	defer close(done)
-	go func() {
-		s.vr.SetValue(mustCalcTwoValuesByOperation(s.left.GetValue(), s.right.GetValue(), s.op))
+	for _, argument := range []model.Argument{s.left, s.right} {
+		dependency, ok := argument.(*model.Variable)
+		if !ok {
+			continue
+		}
 
-		done <- struct{}{}
-	}()
+		select {
+		case <-ctx.Done():
+			s.Cancel(ctx.Err())
+			return
+		case <-dependency.Done():
+		}
+	}
 
-	select {
-	case <-ctx.Done():
-		return
-	case <-done:
+	if err := ctx.Err(); err != nil {
+		s.Cancel(err)
 		return
 	}
+
+	s.Evaluate()
+}
+
+func (s *Sentence) Cancel(ctxErr error) {
+	s.vr.SetError(s.wrapError(model.CancellationCause(ctxErr)))
+	s.notify()
+}
+
+func (s *Sentence) Evaluate() {
+	if err := s.evaluate(); err != nil {
+		s.vr.SetError(err)
+	}
+
+	s.notify()
+}
+
+func (s *Sentence) notify() {
+	if s.options.Progress != nil {
+		s.options.Progress.Resolve()
+	}
+
+	if s.options.Resolved != nil {
+		s.options.Resolved(s.vr)
+	}
+}
+
+func (s *Sentence) evaluate() error {
+	switch s.options.Numeric {
+	case model.NumericBig:
+		value, err := s.calcBig()
+		if err != nil {
+			return err
+		}
+
+		s.vr.SetBigValue(value)
+		return nil
+	case model.NumericDecimal:
+		value, err := s.calcDecimal()
+		if err != nil {
+			return err
+		}
+
+		s.vr.SetDecimalValue(value)
+		return nil
+	}
+
+	value, err := s.calc()
+	if err != nil {
+		return err
+	}
+
+	s.vr.SetValue(value)
+	return nil
+}
+
+func (s *Sentence) calc() (int64, error) {
+	left, err := s.left.GetValue()
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := s.right.GetValue()
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := calcTwoValuesByOperation(left, right, s.op, s.rounding, s.options.Overflow)
+	if err != nil {
+		return 0, s.wrapError(err)
+	}
+
+	return value, nil
+}
+
+func (s *Sentence) calcBig() (*big.Int, error) {
+	left, err := s.left.GetBigValue()
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := s.right.GetBigValue()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := calcTwoBigValuesByOperation(left, right, s.op, s.rounding, s.maxValueBits())
+	if err != nil {
+		return nil, s.wrapError(err)
+	}
+
+	return value, nil
+}
+
+func (s *Sentence) calcDecimal() (model.Decimal, error) {
+	left, err := s.left.GetDecimalValue()
+	if err != nil {
+		return model.Decimal{}, err
+	}
+
+	right, err := s.right.GetDecimalValue()
+	if err != nil {
+		return model.Decimal{}, err
+	}
+
+	value, err := calcTwoDecimalValuesByOperation(left, right, s.op, s.rounding, s.options.Scale,
+		s.maxValueBits())
+	if err != nil {
+		return model.Decimal{}, s.wrapError(err)
+	}
+
+	return value, nil
+}
+
+func (s *Sentence) maxValueBits() int {
+	if s.options.MaxValueBits > 0 {
+		return s.options.MaxValueBits
+	}
+
+	return model.DefaultMaxValueBits
 }
 
-func mustCalcTwoValuesByOperation(a, b int64, op model.Operation) int64 {
-	switch op {
-	case model.Plus:
-		return a + b
-	case model.Minus:
-		return a - b
-	case model.Multiply:
-		return a * b
+func (s *Sentence) wrapError(err error) error {
+	name := s.vr.GetName()
+	if s.statement != "" {
+		name = s.statement
 	}
 
-	return 0
+	return &model.CalculationError{Var: name, CommandIndex: s.index, Line: s.line, Column: s.column, Err: err}
 }
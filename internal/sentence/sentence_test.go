@@ -0,0 +1,356 @@
+package sentence_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/sentence"
+	"math"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCalc(t *testing.T) {
+	tests := []struct {
+		name          string
+		op            model.Operation
+		rounding      model.RoundingMode
+		overflow      model.OverflowMode
+		left          int64
+		right         int64
+		expectedValue int64
+		expectedErr   error
+	}{
+		{name: "plus", op: model.Plus, left: 2, right: 3, expectedValue: 5},
+		{name: "minus", op: model.Minus, left: 2, right: 3, expectedValue: -1},
+		{name: "multiply", op: model.Multiply, left: -2, right: 3, expectedValue: -6},
+		{name: "divide trunc", op: model.Divide, rounding: model.Trunc, left: -7, right: 2, expectedValue: -3},
+		{name: "divide floor", op: model.Divide, rounding: model.Floor, left: -7, right: 2, expectedValue: -4},
+		{name: "divide ceil", op: model.Divide, rounding: model.Ceil, left: 7, right: 2, expectedValue: 4},
+		{name: "divide euclid", op: model.Divide, rounding: model.Euclid, left: -7, right: -2, expectedValue: 4},
+		{name: "divide exact", op: model.Divide, rounding: model.Floor, left: -8, right: 2, expectedValue: -4},
+		{name: "modulo trunc", op: model.Modulo, rounding: model.Trunc, left: -7, right: 2, expectedValue: -1},
+		{name: "modulo floor", op: model.Modulo, rounding: model.Floor, left: -7, right: 2, expectedValue: 1},
+		{name: "modulo floor negative divisor", op: model.Modulo, rounding: model.Floor, left: 7, right: -2, expectedValue: -1},
+		{name: "modulo ceil", op: model.Modulo, rounding: model.Ceil, left: 7, right: 2, expectedValue: -1},
+		{name: "modulo euclid", op: model.Modulo, rounding: model.Euclid, left: -7, right: -2, expectedValue: 1},
+		{name: "divide by zero", op: model.Divide, left: 1, right: 0, expectedErr: model.ErrDivisionByZero},
+		{name: "modulo by zero", op: model.Modulo, left: 1, right: 0, expectedErr: model.ErrDivisionByZero},
+		{name: "plus overflow", op: model.Plus, left: math.MaxInt64, right: 1, expectedErr: model.ErrOverflow},
+		{name: "plus overflow wrap", op: model.Plus, overflow: model.OverflowWrap, left: math.MaxInt64, right: 1, expectedValue: math.MinInt64},
+		{name: "plus overflow saturate", op: model.Plus, overflow: model.OverflowSaturate, left: math.MaxInt64, right: 1, expectedValue: math.MaxInt64},
+		{name: "plus negative saturate", op: model.Plus, overflow: model.OverflowSaturate, left: math.MinInt64, right: -1, expectedValue: math.MinInt64},
+		{name: "plus without overflow", op: model.Plus, left: math.MaxInt64, right: math.MinInt64, expectedValue: -1},
+		{name: "minus overflow", op: model.Minus, left: math.MinInt64, right: 1, expectedErr: model.ErrOverflow},
+		{name: "minus overflow saturate", op: model.Minus, overflow: model.OverflowSaturate, left: 0, right: math.MinInt64, expectedValue: math.MaxInt64},
+		{name: "minus without overflow", op: model.Minus, left: -1, right: math.MinInt64, expectedValue: math.MaxInt64},
+		{name: "multiply overflow", op: model.Multiply, left: math.MaxInt64 / 2, right: 3, expectedErr: model.ErrOverflow},
+		{name: "multiply min by minus one", op: model.Multiply, left: -1, right: math.MinInt64, expectedErr: model.ErrOverflow},
+		{name: "multiply overflow saturate", op: model.Multiply, overflow: model.OverflowSaturate, left: math.MaxInt64 / 2, right: -3, expectedValue: math.MinInt64},
+		{name: "multiply overflow wrap", op: model.Multiply, overflow: model.OverflowWrap, left: math.MinInt64, right: -1, expectedValue: math.MinInt64},
+		{name: "divide overflow", op: model.Divide, left: math.MinInt64, right: -1, expectedErr: model.ErrOverflow},
+		{name: "divide overflow saturate", op: model.Divide, overflow: model.OverflowSaturate, left: math.MinInt64, right: -1, expectedValue: math.MaxInt64},
+		{name: "modulo min by minus one", op: model.Modulo, left: math.MinInt64, right: -1, expectedValue: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := model.DefaultExecutionOptions()
+			if tt.overflow != "" {
+				options.Overflow = tt.overflow
+			}
+
+			x := model.NewVariable("x")
+			s := sentence.NewSentence(3, model.Command{
+				Type:     model.Calc,
+				Var:      x,
+				Op:       tt.op,
+				Rounding: tt.rounding,
+				Left:     model.NumericArgument(tt.left),
+				Right:    model.NumericArgument(tt.right),
+			}, options)
+
+			s.Calc(context.Background())
+			value, err := x.GetValue()
+
+			if tt.expectedErr != nil {
+				var calcErr *model.CalculationError
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.ErrorAs(t, err, &calcErr)
+				assert.Equal(t, 3, calcErr.CommandIndex)
+				assert.Equal(t, "x", calcErr.Var)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedValue, value)
+		})
+	}
+}
+
+func TestCalcPropagatesDependencyError(t *testing.T) {
+	x := model.NewVariable("x")
+	y := model.NewVariable("y")
+
+	sentence.NewSentence(0, model.Command{
+		Type: model.Calc, Var: x, Op: model.Divide, Left: model.NumericArgument(1), Right: model.NumericArgument(0),
+	}, model.DefaultExecutionOptions()).Calc(context.Background())
+	sentence.NewSentence(1, model.Command{
+		Type: model.Calc, Var: y, Op: model.Plus, Left: model.NumericArgument(1), Right: x,
+	}, model.DefaultExecutionOptions()).Calc(context.Background())
+
+	_, xErr := x.GetValue()
+	_, yErr := y.GetValue()
+
+	assert.ErrorIs(t, yErr, model.ErrDivisionByZero)
+	assert.Equal(t, xErr, yErr)
+}
+
+func TestCalcBig(t *testing.T) {
+	options := model.DefaultExecutionOptions()
+	options.Numeric = model.NumericBig
+
+	x := model.NewVariable("x")
+	y := model.NewVariable("y")
+	z := model.NewVariable("z")
+	w := model.NewVariable("w")
+
+	sentence.NewSentence(0, model.Command{
+		Type: model.Calc, Var: x, Op: model.Multiply,
+		Left: model.NumericArgument(math.MaxInt64), Right: model.NumericArgument(math.MaxInt64),
+	}, options).Calc(context.Background())
+	sentence.NewSentence(1, model.Command{
+		Type: model.Calc, Var: y, Op: model.Divide, Rounding: model.Floor, Left: x, Right: model.NumericArgument(-2),
+	}, options).Calc(context.Background())
+	sentence.NewSentence(2, model.Command{
+		Type: model.Calc, Var: z, Op: model.Modulo, Rounding: model.Euclid, Left: model.NumericArgument(-7), Right: model.NumericArgument(-2),
+	}, options).Calc(context.Background())
+	sentence.NewSentence(3, model.Command{
+		Type: model.Calc, Var: w, Op: model.Divide, Left: y, Right: model.NumericArgument(0),
+	}, options).Calc(context.Background())
+
+	xValue, err := x.GetBigValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "85070591730234615847396907784232501249", xValue.String())
+
+	yValue, err := y.GetBigValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "-42535295865117307923698453892116250625", yValue.String())
+
+	zValue, err := z.GetBigValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", zValue.String())
+
+	_, err = w.GetBigValue()
+	assert.ErrorIs(t, err, model.ErrDivisionByZero)
+}
+
+func TestCalcDecimal(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       model.Operation
+		rounding model.RoundingMode
+		scale    int32
+		left     string
+		right    string
+		expected string
+	}{
+		{name: "plus rescales operands", op: model.Plus, scale: 4, left: "1.5", right: "2.25", expected: "3.7500"},
+		{name: "minus", op: model.Minus, scale: 2, left: "1", right: "2.75", expected: "-1.75"},
+		{name: "multiply truncates", op: model.Multiply, scale: 2, left: "1.23", right: "4.56", expected: "5.60"},
+		{name: "multiply half up", op: model.Multiply, rounding: model.HalfUp, scale: 2, left: "1.23", right: "4.56", expected: "5.61"},
+		{name: "divide half even tie", op: model.Divide, rounding: model.HalfEven, scale: 1, left: "0.25", right: "1", expected: "0.2"},
+		{name: "divide half up tie", op: model.Divide, rounding: model.HalfUp, scale: 1, left: "-0.25", right: "1", expected: "-0.3"},
+		{name: "divide repeating", op: model.Divide, scale: 4, left: "1", right: "3", expected: "0.3333"},
+		{name: "divide floor", op: model.Divide, rounding: model.Floor, scale: 4, left: "-1", right: "3", expected: "-0.3334"},
+		{name: "modulo", op: model.Modulo, scale: 2, left: "7.5", right: "2", expected: "1.50"},
+		{name: "literal beyond scale uses rounding", op: model.Plus, rounding: model.HalfUp, scale: 1, left: "0.06", right: "0", expected: "0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := model.DefaultExecutionOptions()
+			options.Numeric = model.NumericDecimal
+			options.Scale = tt.scale
+
+			left, err := model.ParseDecimal(tt.left)
+			assert.NoError(t, err)
+			right, err := model.ParseDecimal(tt.right)
+			assert.NoError(t, err)
+
+			x := model.NewVariable("x")
+			sentence.NewSentence(0, model.Command{
+				Type:     model.Calc,
+				Var:      x,
+				Op:       tt.op,
+				Rounding: tt.rounding,
+				Left:     model.NewDecimalArgument(left),
+				Right:    model.NewDecimalArgument(right),
+			}, options).Calc(context.Background())
+
+			value, err := x.GetDecimalValue()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value.String())
+		})
+	}
+}
+
+func TestCalcHalfRoundingInt64(t *testing.T) {
+	tests := []struct {
+		name     string
+		rounding model.RoundingMode
+		left     int64
+		right    int64
+		expected int64
+	}{
+		{name: "half up positive tie", rounding: model.HalfUp, left: 5, right: 2, expected: 3},
+		{name: "half up negative tie", rounding: model.HalfUp, left: -5, right: 2, expected: -3},
+		{name: "half even rounds to even", rounding: model.HalfEven, left: 5, right: 2, expected: 2},
+		{name: "half even odd tie", rounding: model.HalfEven, left: 7, right: 2, expected: 4},
+		{name: "half up below half", rounding: model.HalfUp, left: 4, right: 3, expected: 1},
+		{name: "half up above half", rounding: model.HalfUp, left: -5, right: 3, expected: -2},
+		{name: "half up extreme divisor", rounding: model.HalfUp, left: math.MaxInt64, right: math.MinInt64, expected: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := model.NewVariable("x")
+			sentence.NewSentence(0, model.Command{
+				Type:     model.Calc,
+				Var:      x,
+				Op:       model.Divide,
+				Rounding: tt.rounding,
+				Left:     model.NumericArgument(tt.left),
+				Right:    model.NumericArgument(tt.right),
+			}, model.DefaultExecutionOptions()).Calc(context.Background())
+
+			value, err := x.GetValue()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestCalcValueBitsLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		numeric     model.NumericMode
+		op          model.Operation
+		left        model.Argument
+		right       model.Argument
+		expectedErr error
+	}{
+		{name: "big product within limit", numeric: model.NumericBig, op: model.Multiply,
+			left: model.NumericArgument(math.MaxInt64), right: model.NumericArgument(2)},
+		{name: "big product over limit", numeric: model.NumericBig, op: model.Multiply,
+			left: model.NumericArgument(math.MaxInt64), right: model.NumericArgument(4), expectedErr: model.ErrOverflow},
+		{name: "big square rejected before multiplying", numeric: model.NumericBig, op: model.Multiply,
+			left: model.NumericArgument(math.MaxInt64), right: model.NumericArgument(math.MaxInt64), expectedErr: model.ErrOverflow},
+		{name: "big sum within limit", numeric: model.NumericBig, op: model.Plus,
+			left: model.NumericArgument(math.MaxInt64), right: model.NumericArgument(math.MaxInt64)},
+		{name: "decimal product over limit", numeric: model.NumericDecimal, op: model.Multiply,
+			left: model.NumericArgument(math.MaxInt64 / 100), right: model.NumericArgument(4), expectedErr: model.ErrOverflow},
+		{name: "decimal quotient within limit", numeric: model.NumericDecimal, op: model.Divide,
+			left: model.NumericArgument(math.MaxInt64 / 100), right: model.NumericArgument(2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := model.DefaultExecutionOptions()
+			options.Numeric = tt.numeric
+			options.Scale = 2
+			options.MaxValueBits = 64
+
+			x := model.NewVariable("x")
+			sentence.NewSentence(0, model.Command{
+				Type: model.Calc, Var: x, Op: tt.op, Left: tt.left, Right: tt.right,
+			}, options).Calc(context.Background())
+
+			_, err := x.GetDecimalValue()
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCalcCancelled(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      func() (context.Context, context.CancelFunc)
+		expected model.VariableStatus
+		cause    error
+	}{
+		{
+			name: "cancelled",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			expected: model.StatusCancelled,
+			cause:    model.ErrCancelled,
+		},
+		{
+			name: "deadline exceeded",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 0)
+			},
+			expected: model.StatusTimeout,
+			cause:    model.ErrTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			cancel()
+
+			dependency := model.NewVariable("a")
+			x := model.NewVariable("x")
+			sentence.NewSentence(0, model.Command{
+				Type: model.Calc, Var: x, Op: model.Plus, Left: dependency, Right: model.NumericArgument(1),
+			}, model.DefaultExecutionOptions()).Calc(ctx)
+
+			assert.Equal(t, tt.expected, x.Status())
+			_, err := x.GetValue()
+			assert.ErrorIs(t, err, tt.cause)
+
+			dependency.SetValue(1)
+			value, err := x.GetValue()
+			assert.Equal(t, int64(0), value)
+			assert.ErrorIs(t, err, tt.cause)
+		})
+	}
+}
+
+func TestCalcCancelledReleasesGoroutine(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	dependency := model.NewVariable("a")
+	x := model.NewVariable("x")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		sentence.NewSentence(0, model.Command{
+			Type: model.Calc, Var: x, Op: model.Plus, Left: model.NumericArgument(1), Right: dependency,
+		}, model.DefaultExecutionOptions()).Calc(ctx)
+	}()
+
+	cancel()
+	<-done
+
+	assert.Equal(t, model.StatusCancelled, x.Status())
+	assert.False(t, dependency.IsResolved())
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+}
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"industrial-calculator/internal/model"
+)
+
+func executionErrorToStatus(err error) error {
+	if !errors.Is(err, model.ErrInvalidProgram) {
+		return status.Error(codes.Internal, "failed to execute instructions")
+	}
+
+	details := model.ErrorDetails(err)
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(details))
+	for i, detail := range details {
+		violations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       fieldPath(detail),
+			Description: detail.Message,
+			Reason:      string(detail.Code),
+		}
+	}
+
+	st, detailErr := status.New(codes.InvalidArgument, err.Error()).
+		WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return st.Err()
+}
+
+func fieldPath(detail model.ErrorDetail) string {
+	switch {
+	case detail.CommandIndex != model.NoCommandIndex && detail.Field != "":
+		return fmt.Sprintf("commands[%d].%s", detail.CommandIndex, detail.Field)
+	case detail.CommandIndex != model.NoCommandIndex:
+		return fmt.Sprintf("commands[%d]", detail.CommandIndex)
+	case detail.Line > 0:
+		return "program"
+	case detail.Field != "":
+		return detail.Field
+	default:
+		return "commands"
+	}
+}
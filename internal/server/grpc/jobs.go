@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	api "industrial-calculator/api/industrial-calculator.v1"
+	"industrial-calculator/internal/model"
+)
+
+type jobManager interface {
+	Submit(commands []model.Command, options model.ExecutionOptions) (model.Job, error)
+	Get(id string) (model.Job, error)
+	Cancel(id string) (model.Job, error)
+}
+
+func (s *CalcExecutorServer) SubmitJob(ctx context.Context, req *api.ProcessRequest) (*api.JobResponse, error) {
+	options, err := parseExecutionOptions(ctx, req.Overflow, req.Numeric, req.Scale, req.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := s.builder.Build(buildInstructions(req.Commands), options)
+	if err != nil {
+		return nil, executionErrorToStatus(err)
+	}
+
+	job, err := s.jobs.Submit(commands, options)
+	if err != nil {
+		return nil, jobErrorToStatus(err)
+	}
+
+	return buildJobResponse(job), nil
+}
+
+func (s *CalcExecutorServer) GetJob(_ context.Context, req *api.JobRequest) (*api.JobResponse, error) {
+	job, err := s.jobs.Get(req.Id)
+	if err != nil {
+		return nil, jobErrorToStatus(err)
+	}
+
+	return buildJobResponse(job), nil
+}
+
+func (s *CalcExecutorServer) CancelJob(_ context.Context, req *api.JobRequest) (*api.JobResponse, error) {
+	job, err := s.jobs.Cancel(req.Id)
+	if err != nil {
+		return nil, jobErrorToStatus(err)
+	}
+
+	return buildJobResponse(job), nil
+}
+
+func jobErrorToStatus(err error) error {
+	if errors.Is(err, model.ErrJobNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	if errors.Is(err, model.ErrTooManyJobs) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	return executionErrorToStatus(err)
+}
+
+func buildJobResponse(job model.Job) *api.JobResponse {
+	response := &api.JobResponse{
+		Id:              job.ID,
+		Status:          jobStatuses[job.Status],
+		Resolved:        int32(job.Resolved),
+		Required:        int32(job.Required),
+		CreatedAtUnixMs: job.CreatedAt.UnixMilli(),
+	}
+
+	if job.IsFinished() {
+		response.FinishedAtUnixMs = job.FinishedAt.UnixMilli()
+		response.Results = buildResponse(job.Results, job.Options).Results
+	}
+
+	if job.Err != nil {
+		response.Error = job.Err.Error()
+	}
+
+	return response
+}
@@ -0,0 +1,144 @@
+package grpc
+
+import (
+	api "industrial-calculator/api/industrial-calculator.v1"
+	"industrial-calculator/internal/model"
+	"strconv"
+)
+
+var commandTypes = map[api.CommandType]model.CommandType{
+	api.CommandType_PRINT: model.Print,
+	api.CommandType_CALC:  model.Calc,
+}
+
+var operationSymbols = map[api.Operation]string{
+	api.Operation_PLUS:     "+",
+	api.Operation_MINUS:    "-",
+	api.Operation_MULTIPLY: "*",
+	api.Operation_DIVIDE:   "/",
+	api.Operation_MODULO:   "%",
+}
+
+var roundingModes = map[api.Rounding]model.RoundingMode{
+	api.Rounding_TRUNC:     model.Trunc,
+	api.Rounding_FLOOR:     model.Floor,
+	api.Rounding_CEIL:      model.Ceil,
+	api.Rounding_EUCLID:    model.Euclid,
+	api.Rounding_HALF_UP:   model.HalfUp,
+	api.Rounding_HALF_EVEN: model.HalfEven,
+}
+
+var overflowModes = map[api.OverflowMode]model.OverflowMode{
+	api.OverflowMode_OVERFLOW_ERROR:    model.OverflowError,
+	api.OverflowMode_OVERFLOW_WRAP:     model.OverflowWrap,
+	api.OverflowMode_OVERFLOW_SATURATE: model.OverflowSaturate,
+}
+
+var numericModes = map[api.NumericMode]model.NumericMode{
+	api.NumericMode_NUMERIC_INT64:   model.NumericInt64,
+	api.NumericMode_NUMERIC_BIG:     model.NumericBig,
+	api.NumericMode_NUMERIC_DECIMAL: model.NumericDecimal,
+}
+
+var executionModes = map[api.ExecutionMode]model.ExecutionMode{
+	api.ExecutionMode_EXECUTION_MODE_DEFAULT:    "",
+	api.ExecutionMode_EXECUTION_MODE_CONCURRENT: model.ModeConcurrent,
+	api.ExecutionMode_EXECUTION_MODE_SEQUENTIAL: model.ModeSequential,
+}
+
+var variableStatuses = map[model.VariableStatus]api.VariableStatus{
+	model.StatusOK:        api.VariableStatus_STATUS_OK,
+	model.StatusError:     api.VariableStatus_STATUS_ERROR,
+	model.StatusTimeout:   api.VariableStatus_STATUS_TIMEOUT,
+	model.StatusCancelled: api.VariableStatus_STATUS_CANCELLED,
+}
+
+var jobStatuses = map[model.JobStatus]api.JobStatus{
+	model.JobRunning:   api.JobStatus_JOB_RUNNING,
+	model.JobDone:      api.JobStatus_JOB_DONE,
+	model.JobFailed:    api.JobStatus_JOB_FAILED,
+	model.JobCancelled: api.JobStatus_JOB_CANCELLED,
+}
+
+func buildInstructions(commands []*api.Command) []model.Instruction {
+	instructions := make([]model.Instruction, len(commands))
+	for i, cmd := range commands {
+		instructions[i] = buildInstruction(cmd)
+	}
+
+	return instructions
+}
+
+func buildInstruction(cmd *api.Command) model.Instruction {
+	return model.Instruction{
+		Type:     mapCommandType(cmd.Type),
+		Var:      cmd.Var,
+		Op:       mapOperation(cmd.Op),
+		Rounding: mapRounding(cmd.Rounding),
+		Left:     buildOperand(cmd.GetLeft()),
+		Right:    buildOperand(cmd.GetRight()),
+	}
+}
+
+func mapCommandType(commandType api.CommandType) model.CommandType {
+	if mapped, ok := commandTypes[commandType]; ok {
+		return mapped
+	}
+
+	return model.CommandType(commandType.String())
+}
+
+func mapOperation(op api.Operation) string {
+	if symbol, ok := operationSymbols[op]; ok {
+		return symbol
+	}
+
+	return op.String()
+}
+
+func mapRounding(rounding api.Rounding) model.RoundingMode {
+	if mapped, ok := roundingModes[rounding]; ok {
+		return mapped
+	}
+
+	return model.RoundingMode(rounding.String())
+}
+
+func buildOperand(arg interface{}) model.Operand {
+	switch v := arg.(type) {
+	case *api.Command_LeftInt:
+		return model.LiteralOperand(strconv.FormatInt(v.LeftInt, 10))
+	case *api.Command_RightInt:
+		return model.LiteralOperand(strconv.FormatInt(v.RightInt, 10))
+	case *api.Command_LeftStr:
+		return model.NameOperand(v.LeftStr)
+	case *api.Command_RightStr:
+		return model.NameOperand(v.RightStr)
+	case *api.Command_LeftDecimal:
+		return model.LiteralOperand(v.LeftDecimal)
+	case *api.Command_RightDecimal:
+		return model.LiteralOperand(v.RightDecimal)
+	default:
+		return model.Operand{}
+	}
+}
+
+func mapOperationToAPI(op model.Operation) api.Operation {
+	for apiOp, symbol := range operationSymbols {
+		if symbol == op.Symbol() {
+			return apiOp
+		}
+	}
+
+	return api.Operation_PLUS
+}
+
+func mapRoundingToAPI(rounding model.RoundingMode) api.Rounding {
+	for apiRounding, mode := range roundingModes {
+		if mode == rounding {
+			return apiRounding
+		}
+	}
+
+	return api.Rounding_TRUNC
+}
@@ -3,24 +3,50 @@ package grpc
 import (
 	"context"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/metadata"
 	api "industrial-calculator/api/industrial-calculator.v1"
 	"industrial-calculator/internal/model"
 	"net"
+	"strconv"
+	"time"
 )
 
+const timeoutMetadataKey = "x-request-timeout"
+
 type CalcExecutorServer struct {
 	api.UnimplementedIndustrialCalculatorServer
-	uc calcExecutorUsecase
+	uc       calcExecutorUsecase
+	builder  programBuilder
+	parser   expressionParser
+	jobs     jobManager
+	sessions sessionManager
 }
 
 type calcExecutorUsecase interface {
-	ExecuteInstructions(ctx context.Context, commands []model.Command) []*model.Variable
+	ExecuteInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+	) ([]*model.Variable, error)
+	ExplainInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+	) ([]*model.Derivation, error)
+	PlanInstructions(commands []model.Command) (*model.Plan, error)
+	StartInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+	) ([]*model.Variable, <-chan int, error)
+}
+
+type programBuilder interface {
+	Build(instructions []model.Instruction, options model.ExecutionOptions) ([]model.Command, error)
+	BuildCommand(index int, instruction model.Instruction, vars map[string]*model.Variable,
+		options model.ExecutionOptions,
+	) (model.Command, error)
+}
+
+type expressionParser interface {
+	Parse(source string, options model.ExecutionOptions) ([]model.Command, error)
 }
 
-func NewCalcExecutorServer(usecase calcExecutorUsecase) *CalcExecutorServer {
-	return &CalcExecutorServer{uc: usecase}
+func NewCalcExecutorServer(usecase calcExecutorUsecase, builder programBuilder, parser expressionParser,
+	jobs jobManager, sessions sessionManager,
+) *CalcExecutorServer {
+	return &CalcExecutorServer{uc: usecase, builder: builder, parser: parser, jobs: jobs, sessions: sessions}
 }
 
 func StartGRPCServer(handler *CalcExecutorServer) error {
@@ -29,72 +55,291 @@ func StartGRPCServer(handler *CalcExecutorServer) error {
 		return err
 	}
 
+	return NewGRPCServer(handler).Serve(lis)
+}
+
+func NewGRPCServer(handler *CalcExecutorServer) *grpc.Server {
 	srv := grpc.NewServer()
 	api.RegisterIndustrialCalculatorServer(srv, handler)
-	return srv.Serve(lis)
+	return srv
 }
 
 func (s *CalcExecutorServer) Process(ctx context.Context, req *api.ProcessRequest) (*api.ProcessResponse, error) {
-	commands := make([]model.Command, 0, len(req.Commands))
-	vars := make(map[string]*model.Variable)
+	options, err := parseExecutionOptions(ctx, req.Overflow, req.Numeric, req.Scale, req.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := s.builder.Build(buildInstructions(req.Commands), options)
+	if err != nil {
+		return nil, executionErrorToStatus(err)
+	}
+
+	result, err := s.uc.ExecuteInstructions(ctx, commands, options)
+	if err != nil {
+		return nil, executionErrorToStatus(err)
+	}
+
+	return buildResponse(result, options), nil
+}
+
+func (s *CalcExecutorServer) ProcessExpression(ctx context.Context, req *api.ProcessExpressionRequest,
+) (*api.ProcessResponse, error) {
+	options, err := parseExecutionOptions(ctx, req.Overflow, req.Numeric, req.Scale, req.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := s.parser.Parse(req.Program, options)
+	if err != nil {
+		return nil, executionErrorToStatus(err)
+	}
+
+	result, err := s.uc.ExecuteInstructions(ctx, commands, options)
+	if err != nil {
+		return nil, executionErrorToStatus(err)
+	}
+
+	return buildResponse(result, options), nil
+}
+
+func (s *CalcExecutorServer) Explain(ctx context.Context, req *api.ProcessRequest) (*api.ExplainResponse, error) {
+	options, err := parseExecutionOptions(ctx, req.Overflow, req.Numeric, req.Scale, req.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := s.builder.Build(buildInstructions(req.Commands), options)
+	if err != nil {
+		return nil, executionErrorToStatus(err)
+	}
+
+	derivations, err := s.uc.ExplainInstructions(ctx, commands, options)
+	if err != nil {
+		return nil, executionErrorToStatus(err)
+	}
+
+	response := &api.ExplainResponse{Derivations: make([]*api.Derivation, len(derivations))}
+	for i, derivation := range derivations {
+		response.Derivations[i] = buildDerivation(derivation, options)
+	}
+
+	return response, nil
+}
+
+func (s *CalcExecutorServer) Plan(ctx context.Context, req *api.ProcessRequest) (*api.PlanResponse, error) {
+	options, err := parseExecutionOptions(ctx, req.Overflow, req.Numeric, req.Scale, req.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := s.builder.Build(buildInstructions(req.Commands), options)
+	if err != nil {
+		return nil, executionErrorToStatus(err)
+	}
+
+	plan, err := s.uc.PlanInstructions(commands)
+	if err != nil {
+		return nil, executionErrorToStatus(err)
+	}
+
+	response := &api.PlanResponse{
+		Required:           buildPlannedCommands(plan.Required),
+		Levels:             make([]*api.PlanLevel, len(plan.Levels)),
+		CriticalPathLength: int32(len(plan.CriticalPath)),
+		CriticalPath:       buildPlannedCommands(plan.CriticalPath),
+		Dead:               buildPlannedCommands(plan.Dead),
+	}
+
+	for i, level := range plan.Levels {
+		response.Levels[i] = &api.PlanLevel{Commands: buildPlannedCommands(level)}
+	}
+
+	return response, nil
+}
+
+func (s *CalcExecutorServer) ProcessStream(req *api.ProcessRequest,
+	stream api.IndustrialCalculator_ProcessStreamServer,
+) error {
+	started := time.Now()
+	ctx := stream.Context()
+
+	options, err := parseExecutionOptions(ctx, req.Overflow, req.Numeric, req.Scale, req.Mode)
+	if err != nil {
+		return err
+	}
+
+	commands, err := s.builder.Build(buildInstructions(req.Commands), options)
+	if err != nil {
+		return executionErrorToStatus(err)
+	}
+
+	targets, resolved, err := s.uc.StartInstructions(ctx, commands, options)
+	if err != nil {
+		return executionErrorToStatus(err)
+	}
+
+	summary := &api.StreamSummary{Total: int32(len(targets))}
+	for index := range resolved {
+		result := buildVariableResult(targets[index], options)
+		result.Index = int32(index)
+		countStatus(summary, result.Status)
 
-	for _, cmd := range req.Commands {
-		if _, exists := vars[cmd.Var]; !exists {
-			vars[cmd.Var] = model.NewVariable(cmd.Var)
+		if err := stream.Send(&api.ProcessStreamResponse{Event: &api.ProcessStreamResponse_Result{Result: result}}); err != nil {
+			return err
 		}
+	}
+
+	summary.ElapsedMs = time.Since(started).Milliseconds()
+
+	return stream.Send(&api.ProcessStreamResponse{Event: &api.ProcessStreamResponse_Summary{Summary: summary}})
+}
+
+func countStatus(summary *api.StreamSummary, status api.VariableStatus) {
+	switch status {
+	case api.VariableStatus_STATUS_OK:
+		summary.Ok++
+	case api.VariableStatus_STATUS_ERROR:
+		summary.Errors++
+	case api.VariableStatus_STATUS_TIMEOUT:
+		summary.Timeouts++
+	case api.VariableStatus_STATUS_CANCELLED:
+		summary.Cancelled++
+	}
+}
 
-		if cmd.Type == api.CommandType_PRINT {
-			commands = append(commands, model.Command{
-				Type: model.CommandType(cmd.Type),
-				Var:  vars[cmd.Var],
-			})
-			continue
+func parseExecutionOptions(ctx context.Context, overflowMode api.OverflowMode, numericMode api.NumericMode,
+	scale *int32, executionMode api.ExecutionMode,
+) (model.ExecutionOptions, error) {
+	options := model.DefaultExecutionOptions()
+	overflow, ok := overflowModes[overflowMode]
+	if !ok {
+		return options, executionErrorToStatus(&model.OptionError{Option: "overflow", Value: overflowMode.String()})
+	}
+	options.Overflow = overflow
+
+	numeric, ok := numericModes[numericMode]
+	if !ok {
+		return options, executionErrorToStatus(&model.OptionError{Option: "numeric", Value: numericMode.String()})
+	}
+	options.Numeric = numeric
+
+	if scale != nil {
+		options.Scale = *scale
+	}
+
+	if !model.IsValidDecimalScale(options.Scale) {
+		return options, executionErrorToStatus(&model.OptionError{Option: "scale",
+			Value: strconv.Itoa(int(options.Scale))})
+	}
+
+	mode, ok := executionModes[executionMode]
+	if !ok {
+		return options, executionErrorToStatus(&model.OptionError{Option: "mode", Value: executionMode.String()})
+	}
+	options.Mode = mode
+
+	if values := metadata.ValueFromIncomingContext(ctx, timeoutMetadataKey); len(values) > 0 {
+		timeout, err := model.ParseTimeout(values[0])
+		if err != nil {
+			return options, executionErrorToStatus(&model.OptionError{Option: "timeout", Value: values[0]})
 		}
 
-		left, err := parseArgument(cmd.GetLeft(), vars)
+		options.Timeout = timeout
+	}
+
+	return options, nil
+}
+
+func buildResponse(vars []*model.Variable, options model.ExecutionOptions) *api.ProcessResponse {
+	results := make([]*api.VariableResult, len(vars))
+	for i, v := range vars {
+		results[i] = buildVariableResult(v, options)
+		results[i].Index = int32(i)
+	}
+	return &api.ProcessResponse{Results: results}
+}
+
+func buildVariableResult(v *model.Variable, options model.ExecutionOptions) *api.VariableResult {
+	result := &api.VariableResult{Var: v.GetName(), Status: variableStatuses[v.Status()]}
+
+	if options.Numeric == model.NumericDecimal {
+		value, err := v.GetDecimalValue()
 		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid left argument: %v", err)
+			result.Error = err.Error()
+			return result
 		}
 
-		right, err := parseArgument(cmd.GetRight(), vars)
+		result.DecimalValue = value.String()
+		return result
+	}
+
+	if options.Numeric == model.NumericBig {
+		value, err := v.GetBigValue()
 		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid right argument: %v", err)
+			result.Error = err.Error()
+			return result
 		}
 
-		commands = append(commands, model.Command{
-			Type:  model.CommandType(cmd.Type),
-			Var:   vars[cmd.Var],
-			Op:    model.Operation(cmd.Op),
-			Left:  left,
-			Right: right,
-		})
+		result.BigValue = value.String()
+		return result
+	}
+
+	value, err := v.GetValue()
+	result.Value = value
+	if err != nil {
+		result.Error = err.Error()
 	}
 
-	result := s.uc.ExecuteInstructions(ctx, commands)
-	return buildResponse(result), nil
+	return result
 }
 
-func parseArgument(arg interface{}, vars map[string]*model.Variable) (model.Argument, error) {
-	switch v := arg.(type) {
-	case *api.Command_LeftInt:
-		return model.NumericArgument(v.LeftInt), nil
-	case *api.Command_LeftStr:
-		if varRef, ok := vars[v.LeftStr]; ok {
-			return varRef, nil
-		}
-		return nil, status.Errorf(codes.InvalidArgument, "variable not found: %s", v.LeftStr)
+func buildDerivation(derivation *model.Derivation, options model.ExecutionOptions) *api.Derivation {
+	if derivation.IsLiteral() {
+		return &api.Derivation{Result: buildLiteralResult(derivation.Literal, options), Literal: true}
+	}
+
+	result := &api.Derivation{
+		Result:       buildVariableResult(derivation.Variable, options),
+		CommandIndex: int32(derivation.CommandIndex),
+		Op:           mapOperationToAPI(derivation.Op),
+		Rounding:     mapRoundingToAPI(derivation.Rounding),
+		Shared:       derivation.Shared,
+	}
+
+	if derivation.Left != nil {
+		result.Left = buildDerivation(derivation.Left, options)
+	}
+
+	if derivation.Right != nil {
+		result.Right = buildDerivation(derivation.Right, options)
+	}
+
+	return result
+}
+
+func buildLiteralResult(literal model.Argument, options model.ExecutionOptions) *api.VariableResult {
+	result := &api.VariableResult{}
+
+	switch options.Numeric {
+	case model.NumericDecimal:
+		value, _ := literal.GetDecimalValue()
+		result.DecimalValue = value.String()
+	case model.NumericBig:
+		value, _ := literal.GetBigValue()
+		result.BigValue = value.String()
 	default:
-		return nil, status.Error(codes.InvalidArgument, "invalid argument type")
+		result.Value, _ = literal.GetValue()
 	}
+
+	return result
 }
 
-func buildResponse(vars []*model.Variable) *api.ProcessResponse {
-	results := make([]*api.VariableResult, len(vars))
-	for i, v := range vars {
-		results[i] = &api.VariableResult{
-			Var:   v.GetName(),
-			Value: v.GetValue(),
-		}
+func buildPlannedCommands(commands []model.PlannedCommand) []*api.PlannedCommand {
+	result := make([]*api.PlannedCommand, len(commands))
+	for i, command := range commands {
+		result[i] = &api.PlannedCommand{CommandIndex: int32(command.CommandIndex), Var: command.Var}
 	}
-	return &api.ProcessResponse{Results: results}
+
+	return result
 }
@@ -0,0 +1,595 @@
+package grpc_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	api "industrial-calculator/api/industrial-calculator.v1"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/expression_parser"
+	"industrial-calculator/internal/job_manager"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_builder"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/server/grpc"
+	"industrial-calculator/internal/session"
+	"industrial-calculator/internal/usecase"
+	"io"
+	"net"
+	"sort"
+	"testing"
+	"time"
+)
+
+func newServer() *grpc.CalcExecutorServer {
+	return newServerWith(assignment_resolver.Reject, model.DefaultMaxTimeout)
+}
+
+func newServerWith(policy assignment_resolver.Policy, maxTimeout time.Duration) *grpc.CalcExecutorServer {
+	finder := required_variables_finder.NewFinder()
+	validator := program_validator.NewValidator()
+	resolver := assignment_resolver.NewResolver(policy)
+	uc := usecase.NewExecutorRouter(model.ModeConcurrent,
+		usecase.NewCalcExectureUsecase(finder, validator, resolver, scheduler.NewPoolScheduler(4), maxTimeout,
+			model.DefaultMaxValueBits),
+		usecase.NewCalcExectureUsecase(finder, validator, resolver, scheduler.NewSequentialScheduler(),
+			maxTimeout, model.DefaultMaxValueBits),
+	)
+
+	return grpc.NewCalcExecutorServer(uc, program_builder.NewBuilder(uc), expression_parser.NewParser(),
+		job_manager.NewManager(uc, time.Hour, time.Hour, job_manager.DefaultLimit),
+		session.NewManager(policy, model.ModeConcurrent, 4, maxTimeout, model.DefaultMaxValueBits))
+}
+
+func newClient(t *testing.T) api.IndustrialCalculatorClient {
+	return newClientFor(t, newServer())
+}
+
+func newClientFor(t *testing.T, handler *grpc.CalcExecutorServer) api.IndustrialCalculatorClient {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewGRPCServer(handler)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpclib.NewClient("passthrough:///bufnet",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return api.NewIndustrialCalculatorClient(conn)
+}
+
+func calc(name string, op api.Operation, left, right interface{}) *api.Command {
+	cmd := &api.Command{Type: api.CommandType_CALC, Var: name, Op: op}
+
+	switch v := left.(type) {
+	case int64:
+		cmd.Left = &api.Command_LeftInt{LeftInt: v}
+	case string:
+		cmd.Left = &api.Command_LeftStr{LeftStr: v}
+	}
+
+	switch v := right.(type) {
+	case int64:
+		cmd.Right = &api.Command_RightInt{RightInt: v}
+	case string:
+		cmd.Right = &api.Command_RightStr{RightStr: v}
+	}
+
+	return cmd
+}
+
+func printVar(name string) *api.Command {
+	return &api.Command{Type: api.CommandType_PRINT, Var: name}
+}
+
+func TestProcess(t *testing.T) {
+	client := newClient(t)
+
+	response, err := client.Process(context.Background(), &api.ProcessRequest{Commands: []*api.Command{
+		calc("x", api.Operation_PLUS, int64(10), int64(2)),
+		calc("y", api.Operation_MINUS, "x", int64(3)),
+		calc("z", api.Operation_MULTIPLY, "x", "y"),
+		calc("q", api.Operation_DIVIDE, "z", int64(5)),
+		calc("r", api.Operation_MODULO, "z", int64(5)),
+		printVar("z"),
+		printVar("q"),
+		printVar("r"),
+	}})
+
+	require.NoError(t, err)
+	require.Len(t, response.Results, 3)
+	assert.Equal(t, "z", response.Results[0].Var)
+	assert.Equal(t, int64(108), response.Results[0].Value)
+	assert.Equal(t, int64(21), response.Results[1].Value)
+	assert.Equal(t, int64(3), response.Results[2].Value)
+}
+
+func TestProcessReportsVariableStatus(t *testing.T) {
+	client := newClient(t)
+
+	response, err := client.Process(context.Background(), &api.ProcessRequest{Commands: []*api.Command{
+		calc("x", api.Operation_PLUS, int64(1), int64(2)),
+		calc("y", api.Operation_DIVIDE, "x", int64(0)),
+		printVar("x"),
+		printVar("y"),
+	}})
+
+	require.NoError(t, err)
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, api.VariableStatus_STATUS_OK, response.Results[0].Status)
+	assert.Empty(t, response.Results[0].Error)
+	assert.Equal(t, api.VariableStatus_STATUS_ERROR, response.Results[1].Status)
+	assert.Equal(t, `command 1: cannot calculate "y": division by zero`, response.Results[1].Error)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	response, err = newServer().Process(ctx, &api.ProcessRequest{Commands: []*api.Command{
+		calc("x", api.Operation_PLUS, int64(1), int64(2)),
+		printVar("x"),
+	}})
+
+	require.NoError(t, err)
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, api.VariableStatus_STATUS_CANCELLED, response.Results[0].Status)
+	assert.Equal(t, `command 0: cannot calculate "x": calculation cancelled`, response.Results[0].Error)
+}
+
+func TestProcessRequestTimeout(t *testing.T) {
+	client := newClient(t)
+	commands := []*api.Command{calc("x", api.Operation_PLUS, int64(1), int64(2)), printVar("x")}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-timeout", "1ns")
+	response, err := client.Process(ctx, &api.ProcessRequest{Commands: commands})
+
+	require.NoError(t, err)
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, api.VariableStatus_STATUS_TIMEOUT, response.Results[0].Status)
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "x-request-timeout", "soon")
+	_, err = client.Process(ctx, &api.ProcessRequest{Commands: commands})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, `invalid value "soon" for option "timeout"`, st.Message())
+}
+
+func TestExplain(t *testing.T) {
+	client := newClient(t)
+
+	response, err := client.Explain(context.Background(), &api.ProcessRequest{Commands: []*api.Command{
+		calc("y", api.Operation_MULTIPLY, "x", "x"),
+		calc("x", api.Operation_DIVIDE, int64(7), int64(2)),
+		printVar("y"),
+	}})
+
+	require.NoError(t, err)
+	require.Len(t, response.Derivations, 1)
+
+	y := response.Derivations[0]
+	assert.Equal(t, "y", y.Result.Var)
+	assert.Equal(t, int64(9), y.Result.Value)
+	assert.Equal(t, int32(0), y.CommandIndex)
+	assert.Equal(t, api.Operation_MULTIPLY, y.Op)
+
+	x := y.Left
+	assert.Equal(t, "x", x.Result.Var)
+	assert.Equal(t, int64(3), x.Result.Value)
+	assert.Equal(t, int32(1), x.CommandIndex)
+	assert.Equal(t, api.Operation_DIVIDE, x.Op)
+	assert.False(t, x.Shared)
+	assert.True(t, x.Left.Literal)
+	assert.Equal(t, int64(7), x.Left.Result.Value)
+	assert.Equal(t, int64(2), x.Right.Result.Value)
+
+	assert.Equal(t, "x", y.Right.Result.Var)
+	assert.True(t, y.Right.Shared)
+	assert.Nil(t, y.Right.Left)
+}
+
+func TestPlan(t *testing.T) {
+	client := newClient(t)
+
+	response, err := client.Plan(context.Background(), &api.ProcessRequest{Commands: []*api.Command{
+		calc("a", api.Operation_PLUS, int64(1), int64(2)),
+		calc("b", api.Operation_MULTIPLY, "a", int64(2)),
+		calc("c", api.Operation_MINUS, int64(5), int64(1)),
+		calc("d", api.Operation_PLUS, "b", "c"),
+		calc("dead", api.Operation_MULTIPLY, "d", int64(10)),
+		printVar("d"),
+	}})
+
+	require.NoError(t, err)
+	assert.Len(t, response.Required, 4)
+	require.Len(t, response.Levels, 3)
+	assert.Len(t, response.Levels[0].Commands, 2)
+	assert.Equal(t, "b", response.Levels[1].Commands[0].Var)
+	assert.Equal(t, int32(3), response.CriticalPathLength)
+	assert.Equal(t, "d", response.CriticalPath[2].Var)
+	require.Len(t, response.Dead, 1)
+	assert.Equal(t, int32(4), response.Dead[0].CommandIndex)
+
+	_, err = client.Plan(context.Background(), &api.ProcessRequest{Commands: []*api.Command{printVar("x")}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestProcessStream(t *testing.T) {
+	client := newClient(t)
+
+	stream, err := client.ProcessStream(context.Background(), &api.ProcessRequest{Commands: []*api.Command{
+		calc("x", api.Operation_PLUS, int64(10), int64(2)),
+		calc("y", api.Operation_DIVIDE, "x", int64(0)),
+		calc("z", api.Operation_MULTIPLY, "x", int64(3)),
+		printVar("z"),
+		printVar("y"),
+		printVar("x"),
+	}})
+	require.NoError(t, err)
+
+	var results []*api.VariableResult
+	var summary *api.StreamSummary
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Nil(t, summary, "summary must be the last message")
+
+		if result := message.GetResult(); result != nil {
+			results = append(results, result)
+		}
+
+		summary = message.GetSummary()
+	}
+
+	require.Len(t, results, 3)
+	sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+	assert.Equal(t, "z", results[0].Var)
+	assert.Equal(t, int64(36), results[0].Value)
+	assert.Equal(t, api.VariableStatus_STATUS_ERROR, results[1].Status)
+	assert.Equal(t, int64(12), results[2].Value)
+
+	require.NotNil(t, summary)
+	assert.Equal(t, int32(3), summary.Total)
+	assert.Equal(t, int32(2), summary.Ok)
+	assert.Equal(t, int32(1), summary.Errors)
+
+	stream, err = client.ProcessStream(context.Background(), &api.ProcessRequest{Commands: []*api.Command{printVar("x")}})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSession(t *testing.T) {
+	client := newClient(t)
+
+	stream, err := client.Session(context.Background())
+	require.NoError(t, err)
+
+	send := func(request *api.SessionRequest) {
+		require.NoError(t, stream.Send(request))
+	}
+	command := func(cmd *api.Command) *api.SessionRequest {
+		return &api.SessionRequest{Event: &api.SessionRequest_Command{Command: cmd}}
+	}
+
+	send(&api.SessionRequest{Event: &api.SessionRequest_Start{Start: &api.SessionStart{}}})
+	send(command(printVar("y")))
+	send(command(calc("y", api.Operation_MULTIPLY, "x", int64(2))))
+	send(command(calc("x", api.Operation_PLUS, int64(1), int64(2))))
+
+	message, err := stream.Recv()
+	require.NoError(t, err)
+	require.NotNil(t, message.GetResult())
+	assert.Equal(t, int32(0), message.GetResult().Index)
+	assert.Equal(t, int64(6), message.GetResult().Value)
+
+	send(command(calc("x", api.Operation_PLUS, int64(5), int64(5))))
+	message, err = stream.Recv()
+	require.NoError(t, err)
+	require.NotNil(t, message.GetRejected())
+	assert.Equal(t, int32(3), message.GetRejected().Index)
+	assert.Equal(t, string(model.CodeReassignment), message.GetRejected().Code)
+
+	send(command(calc("a", api.Operation_PLUS, "b", int64(1))))
+	send(command(calc("b", api.Operation_PLUS, "a", int64(1))))
+	message, err = stream.Recv()
+	require.NoError(t, err)
+	require.NotNil(t, message.GetRejected())
+	assert.Equal(t, int32(5), message.GetRejected().Index)
+	assert.Equal(t, string(model.CodeDependencyCycle), message.GetRejected().Code)
+
+	send(command(printVar("missing")))
+	send(&api.SessionRequest{Event: &api.SessionRequest_End{End: &api.EndOfInput{}}})
+
+	var results []*api.VariableResult
+	var summary *api.StreamSummary
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Nil(t, summary, "summary must be the last message")
+
+		if result := message.GetResult(); result != nil {
+			results = append(results, result)
+		}
+
+		summary = message.GetSummary()
+	}
+
+	require.Len(t, results, 1)
+	assert.Equal(t, int32(6), results[0].Index)
+	assert.Equal(t, api.VariableStatus_STATUS_ERROR, results[0].Status)
+	assert.Contains(t, results[0].Error, "missing")
+
+	require.NotNil(t, summary)
+	assert.Equal(t, int32(2), summary.Total)
+	assert.Equal(t, int32(1), summary.Ok)
+	assert.Equal(t, int32(1), summary.Errors)
+}
+
+func TestSessionVersionedPolicy(t *testing.T) {
+	client := newClientFor(t, newServerWith(assignment_resolver.Versioned, model.DefaultMaxTimeout))
+
+	stream, err := client.Session(context.Background())
+	require.NoError(t, err)
+
+	for _, request := range []*api.SessionRequest{
+		{Event: &api.SessionRequest_Start{Start: &api.SessionStart{Mode: api.ExecutionMode_EXECUTION_MODE_SEQUENTIAL}}},
+		{Event: &api.SessionRequest_Command{Command: calc("x", api.Operation_PLUS, int64(1), int64(2))}},
+		{Event: &api.SessionRequest_Command{Command: calc("x", api.Operation_MULTIPLY, "x", int64(10))}},
+		{Event: &api.SessionRequest_Command{Command: printVar("x")}},
+		{Event: &api.SessionRequest_End{End: &api.EndOfInput{}}},
+	} {
+		require.NoError(t, stream.Send(request))
+	}
+
+	message, err := stream.Recv()
+	require.NoError(t, err)
+	require.NotNil(t, message.GetResult())
+	assert.Equal(t, int32(2), message.GetResult().Index)
+	assert.Equal(t, int64(30), message.GetResult().Value)
+
+	message, err = stream.Recv()
+	require.NoError(t, err)
+	require.NotNil(t, message.GetSummary())
+	assert.Equal(t, int32(1), message.GetSummary().Ok)
+}
+
+func TestSessionRejectsLateStart(t *testing.T) {
+	client := newClient(t)
+
+	stream, err := client.Session(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&api.SessionRequest{Event: &api.SessionRequest_Command{
+		Command: calc("x", api.Operation_PLUS, int64(1), int64(2)),
+	}}))
+	require.NoError(t, stream.Send(&api.SessionRequest{Event: &api.SessionRequest_Start{Start: &api.SessionStart{}}}))
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestJobs(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	submitted, err := client.SubmitJob(ctx, &api.ProcessRequest{Commands: []*api.Command{
+		calc("x", api.Operation_PLUS, int64(10), int64(2)),
+		calc("y", api.Operation_MULTIPLY, "x", int64(3)),
+		printVar("y"),
+	}})
+	require.NoError(t, err)
+	require.NotEmpty(t, submitted.Id)
+	assert.Equal(t, int32(2), submitted.Required)
+
+	var job *api.JobResponse
+	require.Eventually(t, func() bool {
+		job, err = client.GetJob(ctx, &api.JobRequest{Id: submitted.Id})
+		return err == nil && job.Status != api.JobStatus_JOB_RUNNING
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, api.JobStatus_JOB_DONE, job.Status)
+	assert.Equal(t, int32(2), job.Resolved)
+	require.Len(t, job.Results, 1)
+	assert.Equal(t, int64(36), job.Results[0].Value)
+	assert.NotZero(t, job.FinishedAtUnixMs)
+
+	_, err = client.SubmitJob(ctx, &api.ProcessRequest{Commands: []*api.Command{printVar("x")}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetJob(ctx, &api.JobRequest{Id: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.CancelJob(ctx, &api.JobRequest{Id: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestProcessRejectsUnknownEnums(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  *api.Command
+		expected string
+	}{
+		{
+			name:     "unknown command type",
+			command:  &api.Command{Type: api.CommandType(7), Var: "x"},
+			expected: `command 1: field "type": unknown command type "7"`,
+		},
+		{
+			name:     "unknown operation",
+			command:  calc("x", api.Operation(42), int64(1), int64(2)),
+			expected: `command 1: field "op": unknown operation "42"`,
+		},
+		{
+			name: "unknown rounding mode",
+			command: &api.Command{Type: api.CommandType_CALC, Var: "x", Op: api.Operation_DIVIDE,
+				Rounding: api.Rounding(9), Left: &api.Command_LeftInt{LeftInt: 1}, Right: &api.Command_RightInt{RightInt: 2}},
+			expected: `command 1: field "rounding": unknown rounding mode "9"`,
+		},
+	}
+
+	client := newClient(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Process(context.Background(), &api.ProcessRequest{
+				Commands: []*api.Command{calc("y", api.Operation_PLUS, int64(1), int64(2)), tt.command},
+			})
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Equal(t, tt.expected, status.Convert(err).Message())
+		})
+	}
+}
+
+func TestProcessReportsFieldViolations(t *testing.T) {
+	client := newClient(t)
+
+	_, err := client.Process(context.Background(), &api.ProcessRequest{Commands: []*api.Command{
+		calc("x", api.Operation(42), "y", int64(1)),
+		{Type: api.CommandType_CALC, Var: "y", Op: api.Operation_PLUS, Left: &api.Command_LeftInt{LeftInt: 1}},
+		printVar("x"),
+	}})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Equal(t, []string{"commands[0].op", "commands[1].right"}, []string{
+		badRequest.FieldViolations[0].Field, badRequest.FieldViolations[1].Field,
+	})
+	assert.Equal(t, "unknown_operation", badRequest.FieldViolations[0].Reason)
+	assert.Equal(t, "missing_operand", badRequest.FieldViolations[1].Reason)
+	assert.Equal(t, `command 1: field "right": operand is required`, badRequest.FieldViolations[1].Description)
+
+	_, err = client.Process(context.Background(), &api.ProcessRequest{Scale: proto.Int32(31)})
+	st = status.Convert(err)
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "scale", st.Details()[0].(*errdetails.BadRequest).FieldViolations[0].Field)
+}
+
+func TestProcessExecutionOptions(t *testing.T) {
+	client := newClient(t)
+
+	_, err := client.Process(context.Background(), &api.ProcessRequest{Overflow: api.OverflowMode(5)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	response, err := client.Process(context.Background(), &api.ProcessRequest{
+		Numeric:  api.NumericMode_NUMERIC_BIG,
+		Commands: []*api.Command{calc("x", api.Operation_MULTIPLY, int64(9223372036854775807), int64(2)), printVar("x")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "18446744073709551614", response.Results[0].BigValue)
+	_, err = client.Process(context.Background(), &api.ProcessRequest{Mode: api.ExecutionMode(9)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, `invalid value "9" for option "mode"`, status.Convert(err).Message())
+
+	response, err = client.Process(context.Background(), &api.ProcessRequest{
+		Mode: api.ExecutionMode_EXECUTION_MODE_SEQUENTIAL,
+		Commands: []*api.Command{
+			calc("y", api.Operation_MULTIPLY, "x", int64(3)),
+			calc("x", api.Operation_DIVIDE, int64(7), int64(2)),
+			printVar("y"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), response.Results[0].Value)
+	assert.Equal(t, api.VariableStatus_STATUS_OK, response.Results[0].Status)
+}
+
+func TestProcessDecimalScaleAndRounding(t *testing.T) {
+	tests := []struct {
+		name     string
+		rounding api.Rounding
+		scale    *int32
+		expected string
+	}{
+		{name: "default scale", rounding: api.Rounding_HALF_EVEN, expected: "-2.5000"},
+		{name: "zero scale half up", rounding: api.Rounding_HALF_UP, scale: proto.Int32(0), expected: "-3"},
+		{name: "zero scale half even", rounding: api.Rounding_HALF_EVEN, scale: proto.Int32(0), expected: "-2"},
+		{name: "zero scale trunc", rounding: api.Rounding_TRUNC, scale: proto.Int32(0), expected: "-2"},
+	}
+
+	client := newClient(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command := calc("x", api.Operation_DIVIDE, int64(-5), int64(2))
+			command.Rounding = tt.rounding
+
+			response, err := client.Process(context.Background(), &api.ProcessRequest{
+				Numeric:  api.NumericMode_NUMERIC_DECIMAL,
+				Scale:    tt.scale,
+				Commands: []*api.Command{command, printVar("x")},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, response.Results[0].DecimalValue)
+		})
+	}
+}
+
+func TestProcessHalfRounding(t *testing.T) {
+	tests := []struct {
+		name     string
+		rounding api.Rounding
+		left     int64
+		expected int64
+	}{
+		{name: "half up", rounding: api.Rounding_HALF_UP, left: -5, expected: -3},
+		{name: "half even rounds to even", rounding: api.Rounding_HALF_EVEN, left: -5, expected: -2},
+		{name: "half even rounds away", rounding: api.Rounding_HALF_EVEN, left: 7, expected: 4},
+	}
+
+	client := newClient(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command := calc("x", api.Operation_DIVIDE, tt.left, int64(2))
+			command.Rounding = tt.rounding
+
+			response, err := client.Process(context.Background(), &api.ProcessRequest{
+				Commands: []*api.Command{command, printVar("x")},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, response.Results[0].Value)
+		})
+	}
+}
+
+func TestProcessExpression(t *testing.T) {
+	client := newClient(t)
+
+	response, err := client.ProcessExpression(context.Background(), &api.ProcessExpressionRequest{
+		Program: "x = (a + 3) * 2\na = 4\nprint x",
+	})
+	require.NoError(t, err)
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, int64(14), response.Results[0].Value)
+
+	_, err = client.ProcessExpression(context.Background(), &api.ProcessExpressionRequest{Program: "x = (1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, `line 1, column 7: expected ")", got end of input`, status.Convert(err).Message())
+}
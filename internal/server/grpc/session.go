@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	api "industrial-calculator/api/industrial-calculator.v1"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/session"
+	"io"
+	"sync"
+	"time"
+)
+
+type sessionManager interface {
+	Start(ctx context.Context, options model.ExecutionOptions) *session.Session
+}
+
+func (s *CalcExecutorServer) Session(stream api.IndustrialCalculator_SessionServer) error {
+	started := time.Now()
+	ctx := stream.Context()
+	vars := make(map[string]*model.Variable)
+
+	summary := &api.StreamSummary{}
+	responses := make(chan *api.SessionResponse)
+	sent := make(chan error, 1)
+	go func() {
+		var err error
+		for response := range responses {
+			if result := response.GetResult(); result != nil {
+				summary.Total++
+				countStatus(summary, result.Status)
+			}
+
+			if err == nil {
+				err = stream.Send(response)
+			}
+		}
+
+		sent <- err
+	}()
+
+	var calc *session.Session
+	var prints sync.WaitGroup
+	finish := func() error {
+		if calc != nil {
+			calc.Close()
+		}
+
+		prints.Wait()
+		close(responses)
+
+		return <-sent
+	}
+
+	options, err := parseExecutionOptions(ctx, api.OverflowMode_OVERFLOW_ERROR, api.NumericMode_NUMERIC_INT64, nil,
+		api.ExecutionMode_EXECUTION_MODE_DEFAULT)
+	if err != nil {
+		_ = finish()
+		return err
+	}
+
+	index := 0
+
+receive:
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			_ = finish()
+			return err
+		}
+
+		switch event := request.Event.(type) {
+		case *api.SessionRequest_Start:
+			if calc != nil {
+				_ = finish()
+				return status.Error(codes.InvalidArgument, "session start must be the first message")
+			}
+
+			options, err = parseExecutionOptions(ctx, event.Start.Overflow, event.Start.Numeric, event.Start.Scale,
+				event.Start.Mode)
+			if err != nil {
+				_ = finish()
+				return err
+			}
+
+			calc = s.sessions.Start(ctx, options)
+		case *api.SessionRequest_Command:
+			if calc == nil {
+				calc = s.sessions.Start(ctx, options)
+			}
+
+			commandIndex := index
+			index++
+
+			command, err := s.builder.BuildCommand(commandIndex, buildInstruction(event.Command), vars, options)
+			if err == nil {
+				command, err = calc.Submit(commandIndex, command)
+			}
+
+			if err != nil {
+				responses <- &api.SessionResponse{Event: &api.SessionResponse_Rejected{
+					Rejected: buildCommandRejected(commandIndex, err),
+				}}
+				continue
+			}
+
+			if command.IsCalc() {
+				vars[command.Var.GetName()] = command.Var
+			}
+
+			if command.IsPrint() {
+				prints.Add(1)
+				go func() {
+					defer prints.Done()
+					<-command.Var.Done()
+
+					result := buildVariableResult(command.Var, options)
+					result.Index = int32(commandIndex)
+					responses <- &api.SessionResponse{Event: &api.SessionResponse_Result{Result: result}}
+				}()
+			}
+		case *api.SessionRequest_End:
+			break receive
+		}
+	}
+
+	if err := finish(); err != nil {
+		return err
+	}
+
+	summary.ElapsedMs = time.Since(started).Milliseconds()
+
+	return stream.Send(&api.SessionResponse{Event: &api.SessionResponse_Summary{Summary: summary}})
+}
+
+func buildCommandRejected(index int, err error) *api.CommandRejected {
+	code := model.CodeInvalidProgram
+	if details := model.ErrorDetails(err); len(details) == 1 {
+		code = details[0].Code
+	}
+
+	return &api.CommandRejected{Index: int32(index), Code: string(code), Message: err.Error()}
+}
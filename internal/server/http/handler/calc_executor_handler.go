@@ -3,30 +3,52 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"industrial-calculator/internal/model"
+	"mime"
 	"net/http"
+	"strconv"
 	"time"
 )
 
+const timeoutHeader = "X-Request-Timeout"
+
 type CalcExecutorHandler struct {
-	uc calcExecutorUsecase
+	uc      streamingCalcExecutorUsecase
+	builder programBuilder
+	parser  scriptParser
 }
 
 type calcExecutorUsecase interface {
-	ExecuteInstructions(ctx context.Context, commands []model.Command) []*model.Variable
+	ExecuteInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+	) ([]*model.Variable, error)
+}
+
+type streamingCalcExecutorUsecase interface {
+	calcExecutorUsecase
+	StartInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+	) ([]*model.Variable, <-chan int, error)
 }
 
-func NewCalcExecutorHandler(usecase calcExecutorUsecase) *CalcExecutorHandler {
-	return &CalcExecutorHandler{uc: usecase}
+type programBuilder interface {
+	Build(instructions []model.Instruction, options model.ExecutionOptions) ([]model.Command, error)
+}
+
+type scriptParser interface {
+	Parse(source string, options model.ExecutionOptions) ([]model.Command, error)
+}
+
+func NewCalcExecutorHandler(usecase streamingCalcExecutorUsecase, builder programBuilder, parser scriptParser,
+) *CalcExecutorHandler {
+	return &CalcExecutorHandler{uc: usecase, builder: builder, parser: parser}
 }
 
 type Request []struct {
-	Type  string      `json:"type"`
-	Op    string      `json:"op,omitempty"`
-	Var   string      `json:"var"`
-	Left  interface{} `json:"left,omitempty"`
-	Right interface{} `json:"right,omitempty"`
+	Type     string      `json:"type"`
+	Op       string      `json:"op,omitempty"`
+	Rounding string      `json:"rounding,omitempty"`
+	Var      string      `json:"var"`
+	Left     interface{} `json:"left,omitempty"`
+	Right    interface{} `json:"right,omitempty"`
 }
 
 type Response struct {
@@ -34,115 +56,202 @@ type Response struct {
 }
 
 type Item struct {
-	Var   string `json:"var"`
-	Value int64  `json:"value"`
+	Var    string      `json:"var"`
+	Value  interface{} `json:"value"`
+	Status string      `json:"status"`
+	Error  string      `json:"error,omitempty"`
 }
 
 func (h *CalcExecutorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
+	started := time.Now()
+
+	options, err := parseExecutionOptions(w, r)
+	if err != nil {
+		return
+	}
 
-	commands, err := h.ValidateAndTransformRequest(w, r)
+	commands, err := decodeProgram(w, r, options, h.builder, h.parser)
 	if err != nil {
 		return
 	}
 
-	result := h.uc.ExecuteInstructions(ctx, commands)
+	if contentType, write := negotiateStream(r); write != nil {
+		targets, resolved, err := h.uc.StartInstructions(r.Context(), commands, options)
+		if err != nil {
+			writeExecutionError(err, w)
+			return
+		}
 
-	h.writeResponse(result, w)
+		writeStream(w, contentType, write, targets, resolved, options, started)
+		return
+	}
+
+	result, err := h.uc.ExecuteInstructions(r.Context(), commands, options)
+	if err != nil {
+		writeExecutionError(err, w)
+		return
+	}
+
+	writeResponse(result, options, w)
 
 	return
 }
 
-func (h *CalcExecutorHandler) ValidateAndTransformRequest(w http.ResponseWriter, r *http.Request) ([]model.Command, error) {
-	if r.Method != http.MethodPost {
-		err := errors.New("method not allowed")
-		http.Error(w, err.Error(), http.StatusMethodNotAllowed)
+func (h *CalcExecutorHandler) ValidateAndTransformRequest(w http.ResponseWriter, r *http.Request,
+	options model.ExecutionOptions,
+) ([]model.Command, error) {
+	return decodeInstructions(w, r, options, h.builder)
+}
 
-		return nil, err
+func decodeProgram(w http.ResponseWriter, r *http.Request, options model.ExecutionOptions, builder programBuilder,
+	parser scriptParser,
+) ([]model.Command, error) {
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/plain" {
+		return decodeScript(w, r, options, parser)
 	}
 
-	var req Request
-	errRequestBody := errors.New("invalid request body")
+	return decodeInstructions(w, r, options, builder)
+}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, errRequestBody.Error(), http.StatusBadRequest)
-		return nil, errRequestBody
+func decodeInstructions(w http.ResponseWriter, r *http.Request, options model.ExecutionOptions,
+	builder programBuilder,
+) ([]model.Command, error) {
+	if r.Method != http.MethodPost {
+		return nil, writeMethodNotAllowed(w)
 	}
 
-	commands := make([]model.Command, len(req))
-	vars := make(map[string]*model.Variable)
+	var req Request
 
-	for _, cmd := range req {
-		if _, ok := vars[cmd.Var]; !ok {
-			vars[cmd.Var] = model.NewVariable(cmd.Var)
-		}
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&req); err != nil {
+		return nil, writeInvalidRequestBody(w)
 	}
 
+	instructions := make([]model.Instruction, len(req))
 	for i, cmd := range req {
-		if !model.IsValidCommand(model.CommandType(cmd.Type)) {
-			http.Error(w, errRequestBody.Error(), http.StatusBadRequest)
-			return nil, errRequestBody
+		instructions[i] = model.Instruction{
+			Type:     model.CommandType(cmd.Type),
+			Var:      cmd.Var,
+			Op:       cmd.Op,
+			Rounding: model.RoundingMode(cmd.Rounding),
+			Left:     decodeOperand(cmd.Left),
+			Right:    decodeOperand(cmd.Right),
 		}
+	}
 
-		if model.CommandType(cmd.Type) == model.Print {
-			command := model.Command{
-				Type: model.CommandType(cmd.Type),
-				Var:  vars[cmd.Var],
-			}
-			commands[i] = command
+	commands, err := builder.Build(instructions, options)
+	if err != nil {
+		writeExecutionError(err, w)
+		return nil, err
+	}
 
-			continue
-		}
+	return commands, nil
+}
 
-		if !model.IsValidOperationBySymbol(cmd.Op) {
-			http.Error(w, errRequestBody.Error(), http.StatusBadRequest)
-			return nil, errRequestBody
-		}
+func decodeScript(w http.ResponseWriter, r *http.Request, options model.ExecutionOptions, parser scriptParser,
+) ([]model.Command, error) {
+	if r.Method != http.MethodPost {
+		return nil, writeMethodNotAllowed(w)
+	}
+
+	source, err := readProgram(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := parser.Parse(source, options)
+	if err != nil {
+		writeExecutionError(err, w)
+		return nil, err
+	}
+
+	return commands, nil
+}
+
+func decodeOperand(value interface{}) model.Operand {
+	switch v := value.(type) {
+	case nil:
+		return model.Operand{}
+	case string:
+		return model.NameOperand(v)
+	case json.Number:
+		return model.NumberOperand(v.String())
+	default:
+		return model.UnsupportedOperand()
+	}
+}
+
+func parseExecutionOptions(w http.ResponseWriter, r *http.Request) (model.ExecutionOptions, error) {
+	options := model.DefaultExecutionOptions()
+	query := r.URL.Query()
+
+	if overflow := query.Get("overflow"); overflow != "" {
+		options.Overflow = model.OverflowMode(overflow)
+	}
 
-		var left model.Argument
-		switch v := cmd.Left.(type) {
-		case string:
-			left = vars[v]
-		case float64:
-			left = model.NumericArgument(v)
-		default:
-			http.Error(w, errRequestBody.Error(), http.StatusBadRequest)
-			return nil, errRequestBody
+	if !model.IsValidOverflowMode(options.Overflow) {
+		err := &model.OptionError{Option: "overflow", Value: string(options.Overflow)}
+		writeExecutionError(err, w)
+		return model.ExecutionOptions{}, err
+	}
+
+	if numeric := query.Get("numeric"); numeric != "" {
+		options.Numeric = model.NumericMode(numeric)
+	}
+
+	if !model.IsValidNumericMode(options.Numeric) {
+		err := &model.OptionError{Option: "numeric", Value: string(options.Numeric)}
+		writeExecutionError(err, w)
+		return model.ExecutionOptions{}, err
+	}
+
+	if scale := query.Get("scale"); scale != "" {
+		value, err := strconv.ParseInt(scale, 10, 32)
+		if err != nil || !model.IsValidDecimalScale(int32(value)) {
+			err := &model.OptionError{Option: "scale", Value: scale}
+			writeExecutionError(err, w)
+			return model.ExecutionOptions{}, err
 		}
 
-		var right model.Argument
-		switch v := cmd.Right.(type) {
-		case string:
-			right = vars[v]
-		case float64:
-			right = model.NumericArgument(v)
-		default:
-			http.Error(w, errRequestBody.Error(), http.StatusBadRequest)
-			return nil, errRequestBody
+		options.Scale = int32(value)
+	}
+
+	if mode := query.Get("mode"); mode != "" {
+		options.Mode = model.ExecutionMode(mode)
+		if !model.IsValidExecutionMode(options.Mode) {
+			err := &model.OptionError{Option: "mode", Value: mode}
+			writeExecutionError(err, w)
+			return model.ExecutionOptions{}, err
 		}
+	}
+
+	timeout := query.Get("timeout")
+	if timeout == "" {
+		timeout = r.Header.Get(timeoutHeader)
+	}
 
-		command := model.Command{
-			Type:  model.CommandType(cmd.Type),
-			Var:   vars[cmd.Var],
-			Op:    model.GetOperationBySymbol(cmd.Op),
-			Left:  left,
-			Right: right,
+	if timeout != "" {
+		value, err := model.ParseTimeout(timeout)
+		if err != nil {
+			err := &model.OptionError{Option: "timeout", Value: timeout}
+			writeExecutionError(err, w)
+			return model.ExecutionOptions{}, err
 		}
 
-		commands[i] = command
+		options.Timeout = value
 	}
 
-	return commands, nil
+	return options, nil
 }
 
-func (h *CalcExecutorHandler) writeResponse(result []*model.Variable, w http.ResponseWriter) {
+func writeResponse(result []*model.Variable, options model.ExecutionOptions,
+	w http.ResponseWriter,
+) {
 	items := make([]Item, len(result))
 	for i := range items {
-		items[i] = Item{
-			Var:   result[i].GetName(),
-			Value: result[i].GetValue(),
-		}
+		items[i] = buildItem(result[i], options)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -152,3 +261,42 @@ func (h *CalcExecutorHandler) writeResponse(result []*model.Variable, w http.Res
 
 	return
 }
+
+func buildItem(variable *model.Variable, options model.ExecutionOptions) Item {
+	item := Item{Var: variable.GetName(), Status: string(variable.Status())}
+
+	value, err := buildValue(variable, options)
+	item.Value = value
+	if err != nil {
+		item.Error = err.Error()
+	}
+
+	return item
+}
+
+func buildValue(argument model.Argument, options model.ExecutionOptions) (interface{}, error) {
+	if options.Numeric == model.NumericDecimal {
+		value, err := argument.GetDecimalValue()
+		if err != nil {
+			return nil, err
+		}
+
+		return value.String(), nil
+	}
+
+	if options.Numeric == model.NumericBig {
+		value, err := argument.GetBigValue()
+		if err != nil {
+			return nil, err
+		}
+
+		return value.String(), nil
+	}
+
+	value, err := argument.GetValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
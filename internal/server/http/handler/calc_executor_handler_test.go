@@ -3,13 +3,24 @@ package handler_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"industrial-calculator/internal/assignment_resolver"
 	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_builder"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/script_parser"
 	"industrial-calculator/internal/server/http/handler"
+	"industrial-calculator/internal/usecase"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestValidateAndTransformRequest(t *testing.T) {
@@ -17,6 +28,7 @@ func TestValidateAndTransformRequest(t *testing.T) {
 		name           string
 		method         string
 		requestBody    string
+		numeric        model.NumericMode
 		expectedStatus int
 		expectedError  error
 	}{
@@ -39,28 +51,58 @@ func TestValidateAndTransformRequest(t *testing.T) {
 			method:         http.MethodPost,
 			requestBody:    `[{"type": "invalid", "var": "x"}]`,
 			expectedStatus: http.StatusBadRequest,
-			expectedError:  errors.New("invalid request body"),
+			expectedError:  errors.New(`command 0: field "type": unknown command type "invalid"`),
 		},
 		{
 			name:           "invalid operation",
 			method:         http.MethodPost,
 			requestBody:    `[{"type": "calc", "op": "invalid", "var": "x", "left": 1, "right": 2}]`,
 			expectedStatus: http.StatusBadRequest,
-			expectedError:  errors.New("invalid request body"),
+			expectedError:  errors.New(`command 0: field "op": unknown operation "invalid"`),
 		},
 		{
 			name:           "invalid left argument type",
 			method:         http.MethodPost,
 			requestBody:    `[{"type": "calc", "op": "+", "var": "x", "left": true, "right": 2}]`,
 			expectedStatus: http.StatusBadRequest,
-			expectedError:  errors.New("invalid request body"),
+			expectedError:  errors.New(`command 0: field "left": operand must be a number or a variable name`),
 		},
 		{
 			name:           "invalid right argument type",
 			method:         http.MethodPost,
 			requestBody:    `[{"type": "calc", "op": "+", "var": "x", "left": 1, "right": false}]`,
 			expectedStatus: http.StatusBadRequest,
-			expectedError:  errors.New("invalid request body"),
+			expectedError:  errors.New(`command 0: field "right": operand must be a number or a variable name`),
+		},
+		{
+			name:           "invalid rounding mode",
+			method:         http.MethodPost,
+			requestBody:    `[{"type": "calc", "op": "/", "rounding": "nearest", "var": "x", "left": 7, "right": 2}]`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  errors.New(`command 0: field "rounding": unknown rounding mode "nearest"`),
+		},
+		{
+			name:           "missing operand",
+			method:         http.MethodPost,
+			requestBody:    `[{"type": "calc", "op": "+", "var": "x", "left": 1}]`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  errors.New(`command 0: field "right": operand is required`),
+		},
+		{
+			name:        "valid division with rounding mode",
+			method:      http.MethodPost,
+			requestBody: `[{"type": "calc", "op": "/", "rounding": "floor", "var": "x", "left": -7, "right": 2}]`,
+		},
+		{
+			name:        "operand naming an unassigned variable",
+			method:      http.MethodPost,
+			requestBody: `[{"type": "calc", "op": "+", "var": "x", "left": "q", "right": 1}]`,
+		},
+		{
+			name:        "decimal literals in decimal mode",
+			method:      http.MethodPost,
+			requestBody: `[{"type": "calc", "op": "*", "var": "x", "left": "1.2500", "right": 0.5}]`,
+			numeric:     model.NumericDecimal,
 		},
 		{
 			name:        "valid print command",
@@ -91,17 +133,22 @@ func TestValidateAndTransformRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUsecase := &mockCalcExecutorUsecase{}
-			h := handler.NewCalcExecutorHandler(mockUsecase)
+			h := handler.NewCalcExecutorHandler(mockUsecase, program_builder.NewBuilder(mockUsecase), script_parser.NewParser())
 
 			req := httptest.NewRequest(tt.method, "/", bytes.NewBufferString(tt.requestBody))
 			req.Header.Set("Content-Type", "application/json")
 			w := httptest.NewRecorder()
 
-			commands, err := h.ValidateAndTransformRequest(w, req)
+			options := model.DefaultExecutionOptions()
+			if tt.numeric != "" {
+				options.Numeric = tt.numeric
+			}
+
+			commands, err := h.ValidateAndTransformRequest(w, req, options)
 
 			if tt.expectedError != nil {
 				assert.Equal(t, tt.expectedStatus, w.Code)
-				assert.Equal(t, tt.expectedError.Error()+"\n", w.Body.String())
+				assert.Equal(t, tt.expectedError.Error(), decodeProblem(t, w).Detail)
 				assert.Nil(t, commands)
 				assert.Error(t, err)
 				return
@@ -116,6 +163,26 @@ func TestValidateAndTransformRequest(t *testing.T) {
 				assert.Equal(t, "x", commands[0].Var.GetName())
 			}
 
+			if tt.name == "valid division with rounding mode" {
+				assert.Len(t, commands, 1)
+				assert.Equal(t, model.Divide, commands[0].Op)
+				assert.Equal(t, model.Floor, commands[0].Rounding)
+			}
+
+			if tt.name == "operand naming an unassigned variable" {
+				assert.Equal(t, "q", commands[0].Left.(*model.Variable).GetName())
+			}
+
+			if tt.name == "decimal literals in decimal mode" {
+				left, err := commands[0].Left.GetDecimalValue()
+				assert.NoError(t, err)
+				assert.Equal(t, "1.2500", left.String())
+
+				right, err := commands[0].Right.GetDecimalValue()
+				assert.NoError(t, err)
+				assert.Equal(t, "0.5", right.String())
+			}
+
 			if tt.name == "mixed valid commands" {
 				assert.Len(t, commands, 3)
 				assert.Equal(t, model.Calc, commands[0].Type)
@@ -125,8 +192,583 @@ func TestValidateAndTransformRequest(t *testing.T) {
 	}
 }
 
-type mockCalcExecutorUsecase struct{}
+func TestServeHTTPExecutionOptions(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		header           string
+		expectedStatus   int
+		expectedOverflow model.OverflowMode
+		expectedTimeout  time.Duration
+		expectedMode     model.ExecutionMode
+	}{
+		{
+			name:             "default overflow mode",
+			expectedStatus:   http.StatusOK,
+			expectedOverflow: model.OverflowError,
+		},
+		{
+			name:             "saturate overflow mode",
+			query:            "?overflow=saturate",
+			expectedStatus:   http.StatusOK,
+			expectedOverflow: model.OverflowSaturate,
+		},
+		{
+			name:           "invalid decimal scale",
+			query:          "?numeric=decimal&scale=31",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid overflow mode",
+			query:          "?overflow=ignore",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "timeout query parameter",
+			query:            "?timeout=250ms",
+			header:           "5s",
+			expectedStatus:   http.StatusOK,
+			expectedOverflow: model.OverflowError,
+			expectedTimeout:  250 * time.Millisecond,
+		},
+		{
+			name:             "timeout header",
+			header:           "1.5s",
+			expectedStatus:   http.StatusOK,
+			expectedOverflow: model.OverflowError,
+			expectedTimeout:  1500 * time.Millisecond,
+		},
+		{
+			name:             "sequential execution mode",
+			query:            "?mode=sequential",
+			expectedStatus:   http.StatusOK,
+			expectedOverflow: model.OverflowError,
+			expectedMode:     model.ModeSequential,
+		},
+		{
+			name:           "invalid execution mode",
+			query:          "?mode=parallel",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid timeout",
+			query:          "?timeout=soon",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "non-positive timeout",
+			header:         "0s",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockCalcExecutorUsecase{}
+			h := handler.NewCalcExecutorHandler(mockUsecase, program_builder.NewBuilder(mockUsecase), script_parser.NewParser())
+
+			body := `[{"type": "calc", "op": "+", "var": "x", "left": 1, "right": 2}]`
+			req := httptest.NewRequest(http.MethodPost, "/process"+tt.query, bytes.NewBufferString(body))
+			if tt.header != "" {
+				req.Header.Set("X-Request-Timeout", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedOverflow, mockUsecase.options.Overflow)
+				assert.Equal(t, tt.expectedTimeout, mockUsecase.options.Timeout)
+				assert.Equal(t, tt.expectedMode, mockUsecase.options.Mode)
+			}
+		})
+	}
+}
+
+func TestServeHTTPResponseEncoding(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct {
+		name         string
+		query        string
+		variable     func() *model.Variable
+		expectedBody string
+	}{
+		{
+			name: "int64 value",
+			variable: func() *model.Variable {
+				v := model.NewVariable("x")
+				v.SetValue(42)
+				return v
+			},
+			expectedBody: `{"items":[{"var":"x","value":42,"status":"ok"}]}`,
+		},
+		{
+			name:  "big value encoded as string",
+			query: "?numeric=big",
+			variable: func() *model.Variable {
+				v := model.NewVariable("x")
+				v.SetBigValue(huge)
+				return v
+			},
+			expectedBody: `{"items":[{"var":"x","value":"123456789012345678901234567890","status":"ok"}]}`,
+		},
+		{
+			name:  "decimal value reported at declared scale",
+			query: "?numeric=decimal&scale=2",
+			variable: func() *model.Variable {
+				v := model.NewVariable("x")
+				v.SetDecimalValue(model.Decimal{Unscaled: big.NewInt(-5), Scale: 2})
+				return v
+			},
+			expectedBody: `{"items":[{"var":"x","value":"-0.05","status":"ok"}]}`,
+		},
+		{
+			name: "calculation error",
+			variable: func() *model.Variable {
+				v := model.NewVariable("x")
+				v.SetError(&model.CalculationError{Var: "x", CommandIndex: 0, Err: model.ErrDivisionByZero})
+				return v
+			},
+			expectedBody: `{"items":[{"var":"x","value":null,"status":"error","error":"command 0: cannot calculate \"x\": division by zero"}]}`,
+		},
+		{
+			name: "timed out calculation",
+			variable: func() *model.Variable {
+				v := model.NewVariable("x")
+				v.SetError(&model.CalculationError{Var: "x", CommandIndex: 0, Err: model.ErrTimeout})
+				return v
+			},
+			expectedBody: `{"items":[{"var":"x","value":null,"status":"timeout","error":"command 0: cannot calculate \"x\": calculation timed out"}]}`,
+		},
+		{
+			name:  "cancelled calculation",
+			query: "?numeric=big",
+			variable: func() *model.Variable {
+				v := model.NewVariable("x")
+				v.SetError(&model.CalculationError{Var: "x", CommandIndex: 0, Err: model.ErrCancelled})
+				return v
+			},
+			expectedBody: `{"items":[{"var":"x","value":null,"status":"cancelled","error":"command 0: cannot calculate \"x\": calculation cancelled"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockCalcExecutorUsecase{result: []*model.Variable{tt.variable()}}
+			h := handler.NewCalcExecutorHandler(mockUsecase, program_builder.NewBuilder(mockUsecase), script_parser.NewParser())
+
+			body := `[{"type": "print", "var": "x"}]`
+			req := httptest.NewRequest(http.MethodPost, "/process"+tt.query, bytes.NewBufferString(body))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestServeHTTPScriptErrorPositions(t *testing.T) {
+	tests := []struct {
+		name     string
+		program  string
+		expected handler.ProblemError
+	}{
+		{
+			name:    "dependency cycle",
+			program: "calc a = b + 1\n\n# comment\ncalc b = a + 1\nprint a",
+			expected: handler.ProblemError{Code: "dependency_cycle", Var: "a", Line: 1, Column: 6,
+				Message: "line 1, column 6: dependency cycle: a -> b -> a"},
+		},
+		{
+			name:    "reassignment",
+			program: "calc y = 1 + 2\n# note\n\n  calc y = 3 + 4\nprint y",
+			expected: handler.ProblemError{Code: "reassignment", Var: "y", Line: 4, Column: 8,
+				Message: `line 4, column 8: variable "y" is already assigned on line 1`},
+		},
+		{
+			name:    "unknown operation",
+			program: "calc y = 1 ^ 2\nprint y",
+			expected: handler.ProblemError{Code: "unknown_operation", Line: 1, Column: 12,
+				Message: `line 1, column 12: unknown operation "^"`},
+		},
+	}
+
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		program_validator.NewValidator(),
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		scheduler.NewSequentialScheduler(),
+		model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits,
+	)
+	h := handler.NewCalcExecutorHandler(uc, program_builder.NewBuilder(uc), script_parser.NewParser())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/process", bytes.NewBufferString(tt.program))
+			req.Header.Set("Content-Type", "text/plain")
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			problem := decodeProblem(t, w)
+			require.Len(t, problem.Errors, 1)
+			assert.Equal(t, tt.expected, problem.Errors[0])
+		})
+	}
+}
 
-func (m *mockCalcExecutorUsecase) ExecuteInstructions(ctx context.Context, commands []model.Command) []*model.Variable {
-	return nil
+func TestServeHTTPReportsErrorsFromEveryStage(t *testing.T) {
+	body := `[
+		{"type": "calc", "var": "x", "op": "^", "left": 1, "right": 2},
+		{"type": "calc", "var": "y", "op": "+", "left": "q", "right": 2},
+		{"type": "calc", "var": "y", "op": "+", "left": 1, "right": 2},
+		{"type": "calc", "var": "a", "op": "+", "left": "b", "right": 1},
+		{"type": "calc", "var": "b", "op": "+", "left": "a", "right": 1},
+		{"type": "print", "var": "y"},
+		{"type": "print", "var": "a"}
+	]`
+
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		program_validator.NewValidator(),
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		scheduler.NewSequentialScheduler(),
+		model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits,
+	)
+	h := handler.NewCalcExecutorHandler(uc, program_builder.NewBuilder(uc), script_parser.NewParser())
+
+	req := httptest.NewRequest(http.MethodPost, "/process", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	problem := decodeProblem(t, w)
+
+	codes := make([]string, len(problem.Errors))
+	for i, problemErr := range problem.Errors {
+		codes[i] = problemErr.Code
+	}
+
+	assert.Equal(t, []string{"unknown_operation", "reassignment", "undefined_variable", "dependency_cycle"}, codes)
+	assert.Equal(t, 0, *problem.Errors[0].CommandIndex)
+	assert.Equal(t, 2, *problem.Errors[1].CommandIndex)
+	assert.Equal(t, 1, *problem.Errors[2].CommandIndex)
+	assert.Equal(t, "q", problem.Errors[2].Var)
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) handler.Problem {
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+	var problem handler.Problem
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+	assert.Equal(t, w.Code, problem.Status)
+
+	return problem
+}
+
+type mockCalcExecutorUsecase struct {
+	commands []model.Command
+	options  model.ExecutionOptions
+	result   []*model.Variable
+}
+
+func (m *mockCalcExecutorUsecase) ExecuteInstructions(ctx context.Context, commands []model.Command,
+	options model.ExecutionOptions,
+) ([]*model.Variable, error) {
+	m.commands = commands
+	m.options = options
+	return m.result, nil
+}
+
+func (m *mockCalcExecutorUsecase) StartInstructions(ctx context.Context, commands []model.Command,
+	options model.ExecutionOptions,
+) ([]*model.Variable, <-chan int, error) {
+	result, err := m.ExecuteInstructions(ctx, commands, options)
+
+	resolved := make(chan int, len(result))
+	for i := range result {
+		resolved <- i
+	}
+	close(resolved)
+
+	return result, resolved, err
+}
+
+func (m *mockCalcExecutorUsecase) PlanInstructions(commands []model.Command) (*model.Plan, error) {
+	return &model.Plan{}, nil
+}
+
+func TestValidateAndTransformRequestLiterals(t *testing.T) {
+	tests := []struct {
+		name          string
+		numeric       model.NumericMode
+		left          string
+		right         string
+		expectedLeft  string
+		expectedRight string
+		expectedError string
+	}{
+		{name: "small integers", left: `1`, right: `-2`, expectedLeft: "1", expectedRight: "-2"},
+		{name: "max int64", left: `9223372036854775807`, right: `0`, expectedLeft: "9223372036854775807", expectedRight: "0"},
+		{name: "min int64", left: `-9223372036854775808`, right: `0`, expectedLeft: "-9223372036854775808", expectedRight: "0"},
+		{name: "above 2^53 is exact", left: `9007199254740993`, right: `0`, expectedLeft: "9007199254740993", expectedRight: "0"},
+		{name: "integral fraction", left: `2.0`, right: `1e3`, expectedLeft: "2", expectedRight: "1000"},
+		{
+			name:          "fraction is rejected",
+			left:          `1.9`,
+			right:         `0`,
+			expectedError: `command 0: field "left": 1.9 is not an integer`,
+		},
+		{
+			name:          "above max int64",
+			left:          `1`,
+			right:         `9223372036854775808`,
+			expectedError: `command 0: field "right": 9223372036854775808 is out of int64 range`,
+		},
+		{
+			name:          "below min int64",
+			left:          `-9223372036854775809`,
+			right:         `1`,
+			expectedError: `command 0: field "left": -9223372036854775809 is out of int64 range`,
+		},
+		{
+			name:          "huge exponent",
+			left:          `1e100000000`,
+			right:         `1`,
+			expectedError: `command 0: field "left": 1e100000000 has too large exponent`,
+		},
+		{
+			name:          "big mode accepts values beyond int64",
+			numeric:       model.NumericBig,
+			left:          `123456789012345678901234567890`,
+			right:         `-9223372036854775809`,
+			expectedLeft:  "123456789012345678901234567890",
+			expectedRight: "-9223372036854775809",
+		},
+		{
+			name:          "big mode rejects fractions",
+			numeric:       model.NumericBig,
+			left:          `1`,
+			right:         `0.5`,
+			expectedError: `command 0: field "right": 0.5 is not an integer`,
+		},
+		{
+			name:          "decimal mode keeps fractional digits",
+			numeric:       model.NumericDecimal,
+			left:          `1.10`,
+			right:         `"0.0001"`,
+			expectedLeft:  "1.10",
+			expectedRight: "0.0001",
+		},
+		{
+			name:          "decimal mode rejects fractional exponent notation",
+			numeric:       model.NumericDecimal,
+			left:          `1.5e-3`,
+			right:         `1`,
+			expectedError: `command 0: field "left": 1.5e-3 must be written in plain decimal notation`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handler.NewCalcExecutorHandler(&mockCalcExecutorUsecase{}, program_builder.NewBuilder(&mockCalcExecutorUsecase{}), script_parser.NewParser())
+
+			body := `[{"type": "calc", "op": "+", "var": "x", "left": ` + tt.left + `, "right": ` + tt.right + `}]`
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+			w := httptest.NewRecorder()
+
+			options := model.DefaultExecutionOptions()
+			if tt.numeric != "" {
+				options.Numeric = tt.numeric
+			}
+
+			commands, err := h.ValidateAndTransformRequest(w, req, options)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Equal(t, tt.expectedError, decodeProblem(t, w).Detail)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, commands, 1)
+
+			left, err := commands[0].Left.GetDecimalValue()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLeft, left.String())
+
+			right, err := commands[0].Right.GetDecimalValue()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRight, right.String())
+		})
+	}
+}
+
+func TestValidateAndTransformRequestLiteralIndex(t *testing.T) {
+	h := handler.NewCalcExecutorHandler(&mockCalcExecutorUsecase{}, program_builder.NewBuilder(&mockCalcExecutorUsecase{}), script_parser.NewParser())
+
+	body := `[
+		{"type": "calc", "op": "+", "var": "x", "left": 1, "right": 2},
+		{"type": "print", "var": "x"},
+		{"type": "calc", "op": "*", "var": "y", "left": "x", "right": 2.5}
+	]`
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	_, err := h.ValidateAndTransformRequest(w, req, model.DefaultExecutionOptions())
+
+	assert.EqualError(t, err, `command 2: field "right": 2.5 is not an integer`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServeHTTPPlainTextScript(t *testing.T) {
+	tests := []struct {
+		name             string
+		contentType      string
+		program          string
+		expectedStatus   int
+		expectedBody     string
+		expectedCommands int
+	}{
+		{
+			name:             "valid script",
+			contentType:      "text/plain; charset=utf-8",
+			program:          "# sum\ncalc x = a + 1\ncalc a = 2 * 3\nprint x\n",
+			expectedStatus:   http.StatusOK,
+			expectedBody:     "{\"items\":[]}\n",
+			expectedCommands: 3,
+		},
+		{
+			name:           "syntax error with position",
+			contentType:    "text/plain",
+			program:        "calc x = 1 + 2\ncalc y = x ^ 2",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "line 2, column 12: unknown operation \"^\"",
+		},
+		{
+			name:           "undefined variable with position",
+			contentType:    "text/plain",
+			program:        "calc x = 1 + 2\nprint y",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "line 2, column 7: variable \"y\" is never assigned",
+		},
+		{
+			name:             "script at size limit",
+			contentType:      "text/plain",
+			program:          padProgram("calc x = 1 + 2\nprint x\n", 1<<20),
+			expectedStatus:   http.StatusOK,
+			expectedBody:     "{\"items\":[]}\n",
+			expectedCommands: 2,
+		},
+		{
+			name:           "script over size limit",
+			contentType:    "text/plain",
+			program:        padProgram("print x\n", 1<<20-len("calc x = 1 + 1234\n")+1) + "calc x = 1 + 1234\n",
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   "program exceeds 1048576 bytes",
+		},
+		{
+			name:           "json is still the default",
+			program:        "calc x = 1 + 2",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid request body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockCalcExecutorUsecase{result: []*model.Variable{}}
+			h := handler.NewCalcExecutorHandler(mockUsecase, program_builder.NewBuilder(mockUsecase), script_parser.NewParser())
+
+			req := httptest.NewRequest(http.MethodPost, "/process", bytes.NewBufferString(tt.program))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			} else {
+				assert.Equal(t, tt.expectedBody, decodeProblem(t, w).Detail)
+			}
+			assert.Len(t, mockUsecase.commands, tt.expectedCommands)
+		})
+	}
+}
+
+func TestServeHTTPProblemDetails(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name     string
+		query    string
+		body     string
+		expected handler.Problem
+	}{
+		{
+			name: "all validation errors at once",
+			body: `[
+				{"type": "calc", "op": "^", "var": "x", "left": 1, "right": true},
+				{"type": "show", "var": "x"}
+			]`,
+			expected: handler.Problem{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: `command 0: field "op": unknown operation "^"` + "\n" +
+					`command 0: field "right": operand must be a number or a variable name` + "\n" +
+					`command 1: field "type": unknown command type "show"`,
+				Code: "invalid_program",
+				Errors: []handler.ProblemError{
+					{Code: "unknown_operation", Message: `command 0: field "op": unknown operation "^"`,
+						CommandIndex: intPtr(0), Field: "op"},
+					{Code: "invalid_operand", Message: `command 0: field "right": operand must be a number or a variable name`,
+						CommandIndex: intPtr(0), Field: "right"},
+					{Code: "unknown_command_type", Message: `command 1: field "type": unknown command type "show"`,
+						CommandIndex: intPtr(1), Field: "type"},
+				},
+			},
+		},
+		{
+			name:  "invalid option",
+			query: "?numeric=float",
+			body:  `[]`,
+			expected: handler.Problem{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: `invalid value "float" for option "numeric"`,
+				Code:   "invalid_option",
+				Errors: []handler.ProblemError{
+					{Code: "invalid_option", Message: `invalid value "float" for option "numeric"`, Field: "numeric"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handler.NewCalcExecutorHandler(&mockCalcExecutorUsecase{}, program_builder.NewBuilder(&mockCalcExecutorUsecase{}),
+				script_parser.NewParser())
+
+			req := httptest.NewRequest(http.MethodPost, "/process"+tt.query, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, decodeProblem(t, w))
+		})
+	}
 }
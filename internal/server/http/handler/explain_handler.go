@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"industrial-calculator/internal/model"
+	"net/http"
+)
+
+type ExplainHandler struct {
+	uc      explainUsecase
+	builder programBuilder
+	parser  scriptParser
+}
+
+type explainUsecase interface {
+	ExplainInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+	) ([]*model.Derivation, error)
+}
+
+func NewExplainHandler(usecase explainUsecase, builder programBuilder, parser scriptParser) *ExplainHandler {
+	return &ExplainHandler{uc: usecase, builder: builder, parser: parser}
+}
+
+type ExplainResponse struct {
+	Derivations []*Derivation `json:"derivations"`
+}
+
+type Derivation struct {
+	Var          string      `json:"var,omitempty"`
+	Value        interface{} `json:"value"`
+	Status       string      `json:"status,omitempty"`
+	Error        string      `json:"error,omitempty"`
+	CommandIndex *int        `json:"command_index,omitempty"`
+	Op           string      `json:"op,omitempty"`
+	Rounding     string      `json:"rounding,omitempty"`
+	Shared       bool        `json:"shared,omitempty"`
+	Left         *Derivation `json:"left,omitempty"`
+	Right        *Derivation `json:"right,omitempty"`
+}
+
+func (h *ExplainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	options, err := parseExecutionOptions(w, r)
+	if err != nil {
+		return
+	}
+
+	commands, err := decodeProgram(w, r, options, h.builder, h.parser)
+	if err != nil {
+		return
+	}
+
+	derivations, err := h.uc.ExplainInstructions(r.Context(), commands, options)
+	if err != nil {
+		writeExecutionError(err, w)
+		return
+	}
+
+	response := ExplainResponse{Derivations: make([]*Derivation, len(derivations))}
+	for i, derivation := range derivations {
+		response.Derivations[i] = buildDerivation(derivation, options)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func buildDerivation(derivation *model.Derivation, options model.ExecutionOptions) *Derivation {
+	if derivation.IsLiteral() {
+		value, _ := buildValue(derivation.Literal, options)
+		return &Derivation{Value: value}
+	}
+
+	item := buildItem(derivation.Variable, options)
+	commandIndex := derivation.CommandIndex
+	result := &Derivation{
+		Var:          item.Var,
+		Value:        item.Value,
+		Status:       item.Status,
+		Error:        item.Error,
+		CommandIndex: &commandIndex,
+		Op:           derivation.Op.Symbol(),
+		Rounding:     string(derivation.Rounding),
+		Shared:       derivation.Shared,
+	}
+
+	if derivation.Left != nil {
+		result.Left = buildDerivation(derivation.Left, options)
+	}
+
+	if derivation.Right != nil {
+		result.Right = buildDerivation(derivation.Right, options)
+	}
+
+	return result
+}
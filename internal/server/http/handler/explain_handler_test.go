@@ -0,0 +1,99 @@
+package handler_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_builder"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/script_parser"
+	"industrial-calculator/internal/server/http/handler"
+	"industrial-calculator/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExplainHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "shared dependency is expanded once",
+			contentType: "text/plain",
+			body: "calc a = 2 + 3\n" +
+				"calc b = a * 2\n" +
+				"calc c = a - 1\n" +
+				"calc d = b * c\n" +
+				"calc unused = 1 + 1\n" +
+				"print d",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"derivations":[{
+				"var":"d","value":40,"status":"ok","command_index":3,"op":"*","rounding":"trunc",
+				"left":{
+					"var":"b","value":10,"status":"ok","command_index":1,"op":"*","rounding":"trunc",
+					"left":{
+						"var":"a","value":5,"status":"ok","command_index":0,"op":"+","rounding":"trunc",
+						"left":{"value":2},"right":{"value":3}
+					},
+					"right":{"value":2}
+				},
+				"right":{
+					"var":"c","value":4,"status":"ok","command_index":2,"op":"-","rounding":"trunc",
+					"left":{"var":"a","value":5,"status":"ok","command_index":0,"op":"+","rounding":"trunc","shared":true},
+					"right":{"value":1}
+				}
+			}]}`,
+		},
+		{
+			name:           "calculation error",
+			contentType:    "application/json",
+			body:           `[{"type":"calc","op":"/","var":"x","left":1,"right":0},{"type":"print","var":"x"}]`,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"derivations":[{
+				"var":"x","value":null,"status":"error","error":"command 0: cannot calculate \"x\": division by zero",
+				"command_index":0,"op":"/","rounding":"trunc","left":{"value":1},"right":{"value":0}
+			}]}`,
+		},
+		{
+			name:           "invalid program",
+			contentType:    "application/json",
+			body:           `[{"type":"print","var":"x"}]`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		program_validator.NewValidator(),
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		scheduler.NewSequentialScheduler(),
+		model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits,
+	)
+	h := handler.NewExplainHandler(uc, program_builder.NewBuilder(uc), script_parser.NewParser())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/explain", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			} else {
+				decodeProblem(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"industrial-calculator/internal/model"
+	"io"
+	"net/http"
+)
+
+const maxProgramSize = 1 << 20
+
+type ExpressionHandler struct {
+	uc     calcExecutorUsecase
+	parser expressionParser
+}
+
+type expressionParser interface {
+	Parse(source string, options model.ExecutionOptions) ([]model.Command, error)
+}
+
+func NewExpressionHandler(usecase calcExecutorUsecase, parser expressionParser) *ExpressionHandler {
+	return &ExpressionHandler{uc: usecase, parser: parser}
+}
+
+func (h *ExpressionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		_ = writeMethodNotAllowed(w)
+		return
+	}
+
+	options, err := parseExecutionOptions(w, r)
+	if err != nil {
+		return
+	}
+
+	source, err := readProgram(w, r)
+	if err != nil {
+		return
+	}
+
+	commands, err := h.parser.Parse(source, options)
+	if err != nil {
+		writeExecutionError(err, w)
+		return
+	}
+
+	result, err := h.uc.ExecuteInstructions(r.Context(), commands, options)
+	if err != nil {
+		writeExecutionError(err, w)
+		return
+	}
+
+	writeResponse(result, options, w)
+}
+
+func readProgram(w http.ResponseWriter, r *http.Request) (string, error) {
+	source, err := io.ReadAll(io.LimitReader(r.Body, maxProgramSize+1))
+	if err != nil {
+		return "", writeInvalidRequestBody(w)
+	}
+
+	if len(source) > maxProgramSize {
+		return "", writeProgramTooLarge(w)
+	}
+
+	return string(source), nil
+}
@@ -0,0 +1,92 @@
+package handler_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/expression_parser"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/server/http/handler"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExpressionHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		program          string
+		expectedStatus   int
+		expectedBody     string
+		expectedCommands int
+	}{
+		{
+			name:           "invalid method",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   "method not allowed",
+		},
+		{
+			name:           "syntax error",
+			method:         http.MethodPost,
+			program:        "x = (1 + 2",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "line 1, column 11: expected \")\", got end of input",
+		},
+		{
+			name:           "undefined variable",
+			method:         http.MethodPost,
+			program:        "a = 1\nx = (a + 3) * b - 2\nprint x",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "line 2, column 15: variable \"b\" is never assigned",
+		},
+		{
+			name:             "valid program",
+			method:           http.MethodPost,
+			program:          "x = (a + 3) * b - 2\na = 1\nb = 2\nprint x",
+			expectedStatus:   http.StatusOK,
+			expectedBody:     "{\"items\":[]}\n",
+			expectedCommands: 6,
+		},
+		{
+			name:             "program at size limit",
+			method:           http.MethodPost,
+			program:          padProgram("x = 1\nprint x\n", 1<<20),
+			expectedStatus:   http.StatusOK,
+			expectedBody:     "{\"items\":[]}\n",
+			expectedCommands: 2,
+		},
+		{
+			name:           "program over size limit",
+			method:         http.MethodPost,
+			program:        padProgram("x = 1\nprint x\n", 1<<20+1),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   "program exceeds 1048576 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockCalcExecutorUsecase{result: []*model.Variable{}}
+			h := handler.NewExpressionHandler(mockUsecase, expression_parser.NewParser())
+
+			req := httptest.NewRequest(tt.method, "/process/expression", bytes.NewBufferString(tt.program))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			} else {
+				assert.Equal(t, tt.expectedBody, decodeProblem(t, w).Detail)
+			}
+			assert.Len(t, mockUsecase.commands, tt.expectedCommands)
+		})
+	}
+}
+
+func padProgram(program string, size int) string {
+	return program + strings.Repeat(" ", size-len(program))
+}
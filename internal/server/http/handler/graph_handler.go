@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"industrial-calculator/internal/graph"
+	"industrial-calculator/internal/model"
+	"io"
+	"net/http"
+)
+
+type GraphHandler struct {
+	graphs  graphBuilder
+	builder programBuilder
+	parser  scriptParser
+}
+
+type graphBuilder interface {
+	Build(commands []model.Command) (*graph.Graph, error)
+}
+
+func NewGraphHandler(graphs graphBuilder, builder programBuilder, parser scriptParser) *GraphHandler {
+	return &GraphHandler{graphs: graphs, builder: builder, parser: parser}
+}
+
+func (h *GraphHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format := graph.FormatDOT
+	if value := r.URL.Query().Get("format"); value != "" {
+		format = graph.Format(value)
+	}
+
+	if !graph.IsValidFormat(format) {
+		writeExecutionError(&model.OptionError{Option: "format", Value: string(format)}, w)
+		return
+	}
+
+	options, err := parseExecutionOptions(w, r)
+	if err != nil {
+		return
+	}
+
+	commands, err := decodeProgram(w, r, options, h.builder, h.parser)
+	if err != nil {
+		return
+	}
+
+	g, err := h.graphs.Build(commands)
+	if err != nil {
+		writeExecutionError(err, w)
+		return
+	}
+
+	rendered, err := graph.Render(g, format)
+	if err != nil {
+		writeExecutionError(err, w)
+		return
+	}
+
+	contentType := "text/vnd.graphviz; charset=utf-8"
+	if format == graph.FormatMermaid {
+		contentType = "text/plain; charset=utf-8"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = io.WriteString(w, rendered)
+}
@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/graph"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_builder"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/script_parser"
+	"industrial-calculator/internal/server/http/handler"
+	"industrial-calculator/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGraphHandler(t *testing.T) {
+	tests := []struct {
+		name                string
+		query               string
+		contentType         string
+		body                string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "dot by default",
+			contentType:         "text/plain",
+			body:                "calc x = 1 + 2\nprint x",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/vnd.graphviz; charset=utf-8",
+			expectedBody: "digraph program {\n" +
+				"  rankdir=LR;\n" +
+				"  op0 [label=\"+\", shape=box];\n" +
+				"  l0left [label=\"1\", shape=plaintext];\n" +
+				"  l0right [label=\"2\", shape=plaintext];\n" +
+				"  v0 [label=\"x\", shape=ellipse, peripheries=2];\n" +
+				"  l0left -> op0 [label=\"left\"];\n" +
+				"  l0right -> op0 [label=\"right\"];\n" +
+				"  op0 -> v0;\n" +
+				"}\n",
+		},
+		{
+			name:                "mermaid with pruned nodes",
+			query:               "?format=mermaid",
+			contentType:         "application/json",
+			body:                `[{"type":"calc","op":"-","var":"y","left":"x","right":1},{"type":"calc","op":"+","var":"x","left":1,"right":2}]`,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBody: "flowchart LR\n" +
+				"  op0[\"-\"]\n" +
+				"  v0([\"x\"])\n" +
+				"  l0right>\"1\"]\n" +
+				"  v1([\"y\"])\n" +
+				"  op1[\"+\"]\n" +
+				"  l1left>\"1\"]\n" +
+				"  l1right>\"2\"]\n" +
+				"  v0 -- left --> op0\n" +
+				"  l0right -- right --> op0\n" +
+				"  op0 --> v1\n" +
+				"  l1left -- left --> op1\n" +
+				"  l1right -- right --> op1\n" +
+				"  op1 --> v0\n" +
+				"  classDef pruned stroke-dasharray:5 5,color:#999\n" +
+				"  class op0,v0,l0right,v1,op1,l1left,l1right pruned\n",
+		},
+		{
+			name:           "unknown format",
+			query:          "?format=svg",
+			contentType:    "text/plain",
+			body:           "calc x = 1 + 2",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "dependency cycle",
+			contentType:    "text/plain",
+			body:           "calc x = y + 1\ncalc y = x + 1\nprint x",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		program_validator.NewValidator(),
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		scheduler.NewSequentialScheduler(),
+		model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits,
+	)
+	h := handler.NewGraphHandler(graph.NewBuilder(uc), program_builder.NewBuilder(uc), script_parser.NewParser())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/graph"+tt.query, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			} else {
+				decodeProblem(t, w)
+			}
+		})
+	}
+}
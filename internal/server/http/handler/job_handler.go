@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"industrial-calculator/internal/model"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const jobsPath = "/jobs"
+
+type JobHandler struct {
+	manager jobManager
+	builder programBuilder
+	parser  scriptParser
+}
+
+type jobManager interface {
+	Submit(commands []model.Command, options model.ExecutionOptions) (model.Job, error)
+	Get(id string) (model.Job, error)
+	Cancel(id string) (model.Job, error)
+}
+
+func NewJobHandler(manager jobManager, builder programBuilder, parser scriptParser) *JobHandler {
+	return &JobHandler{manager: manager, builder: builder, parser: parser}
+}
+
+type JobResponse struct {
+	ID         string      `json:"id"`
+	Status     string      `json:"status"`
+	Progress   JobProgress `json:"progress"`
+	Items      []Item      `json:"items,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+}
+
+type JobProgress struct {
+	Resolved int `json:"resolved"`
+	Required int `json:"required"`
+}
+
+func (h *JobHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, jobsPath), "/")
+	if id == "" {
+		h.submit(w, r)
+		return
+	}
+
+	var job model.Job
+	var err error
+
+	switch r.Method {
+	case http.MethodGet:
+		job, err = h.manager.Get(id)
+	case http.MethodDelete:
+		job, err = h.manager.Cancel(id)
+	default:
+		_ = writeMethodNotAllowed(w)
+		return
+	}
+
+	if errors.Is(err, model.ErrJobNotFound) {
+		writeProblem(w, http.StatusNotFound, model.CodeJobNotFound, err.Error(), nil)
+		return
+	}
+
+	if err != nil {
+		writeExecutionError(err, w)
+		return
+	}
+
+	writeJob(w, http.StatusOK, job)
+}
+
+func (h *JobHandler) submit(w http.ResponseWriter, r *http.Request) {
+	options, err := parseExecutionOptions(w, r)
+	if err != nil {
+		return
+	}
+
+	commands, err := decodeProgram(w, r, options, h.builder, h.parser)
+	if err != nil {
+		return
+	}
+
+	job, err := h.manager.Submit(commands, options)
+	if errors.Is(err, model.ErrTooManyJobs) {
+		writeProblem(w, http.StatusTooManyRequests, model.CodeTooManyJobs, err.Error(), nil)
+		return
+	}
+
+	if err != nil {
+		writeExecutionError(err, w)
+		return
+	}
+
+	w.Header().Set("Location", jobsPath+"/"+job.ID)
+	writeJob(w, http.StatusAccepted, job)
+}
+
+func writeJob(w http.ResponseWriter, status int, job model.Job) {
+	response := JobResponse{
+		ID:        job.ID,
+		Status:    string(job.Status),
+		Progress:  JobProgress{Resolved: job.Resolved, Required: job.Required},
+		CreatedAt: job.CreatedAt,
+	}
+
+	if job.IsFinished() {
+		finishedAt := job.FinishedAt
+		response.FinishedAt = &finishedAt
+
+		response.Items = make([]Item, len(job.Results))
+		for i, variable := range job.Results {
+			response.Items[i] = buildItem(variable, job.Options)
+		}
+	}
+
+	if job.Err != nil {
+		response.Error = job.Err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,200 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/job_manager"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_builder"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/script_parser"
+	"industrial-calculator/internal/server/http/handler"
+	"industrial-calculator/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type calcScheduler interface {
+	Run(ctx context.Context, tasks map[*model.Variable]scheduler.Task, options model.ExecutionOptions)
+}
+
+type slowScheduler struct {
+	delay time.Duration
+	next  calcScheduler
+}
+
+func (s slowScheduler) Run(ctx context.Context, tasks map[*model.Variable]scheduler.Task,
+	options model.ExecutionOptions,
+) {
+	time.Sleep(s.delay)
+	s.next.Run(ctx, tasks, options)
+}
+
+func newJobHandler() *handler.JobHandler {
+	return newJobHandlerWithScheduler(scheduler.NewPoolScheduler(4), model.DefaultMaxTimeout, job_manager.DefaultLimit)
+}
+
+func newJobHandlerWithScheduler(calcScheduler calcScheduler, maxTimeout time.Duration, limit int,
+) *handler.JobHandler {
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		program_validator.NewValidator(),
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		calcScheduler,
+		maxTimeout,
+		model.DefaultMaxValueBits,
+	)
+
+	return handler.NewJobHandler(job_manager.NewManager(uc, time.Hour, time.Hour, limit), program_builder.NewBuilder(uc),
+		script_parser.NewParser())
+}
+
+func awaitJob(t *testing.T, h *handler.JobHandler, id string) handler.JobResponse {
+	var job handler.JobResponse
+	require.Eventually(t, func() bool {
+		var w *httptest.ResponseRecorder
+		w, job = serveJob(t, h, http.MethodGet, "/jobs/"+id, "")
+		return w.Code == http.StatusOK && job.Status != string(model.JobRunning)
+	}, time.Second, time.Millisecond)
+
+	return job
+}
+
+func serveJob(t *testing.T, h *handler.JobHandler, method, path, body string) (*httptest.ResponseRecorder,
+	handler.JobResponse,
+) {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "text/plain")
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, req)
+
+	var response handler.JobResponse
+	if w.Header().Get("Content-Type") == "application/json" {
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	}
+
+	return w, response
+}
+
+func TestJobHandler(t *testing.T) {
+	h := newJobHandler()
+
+	w, submitted := serveJob(t, h, http.MethodPost, "/jobs", "calc x = 10 + 2\ncalc y = x * 3\nprint y")
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.NotEmpty(t, submitted.ID)
+	assert.Equal(t, "/jobs/"+submitted.ID, w.Header().Get("Location"))
+	assert.Equal(t, 2, submitted.Progress.Required)
+
+	job := awaitJob(t, h, submitted.ID)
+	assert.Equal(t, string(model.JobDone), job.Status)
+	assert.Equal(t, handler.JobProgress{Resolved: 2, Required: 2}, job.Progress)
+	require.Len(t, job.Items, 1)
+	assert.Equal(t, "y", job.Items[0].Var)
+	assert.Equal(t, float64(36), job.Items[0].Value)
+	assert.NotNil(t, job.FinishedAt)
+
+	w, job = serveJob(t, h, http.MethodDelete, "/jobs/"+submitted.ID, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, string(model.JobDone), job.Status)
+}
+
+func TestJobHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedCode   model.ErrorCode
+	}{
+		{
+			name:           "invalid program",
+			method:         http.MethodPost,
+			path:           "/jobs",
+			body:           "print x",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   model.CodeUndefinedVariable,
+		},
+		{
+			name:           "submit with wrong method",
+			method:         http.MethodGet,
+			path:           "/jobs",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedCode:   model.CodeMethodNotAllowed,
+		},
+		{
+			name:           "unknown job",
+			method:         http.MethodGet,
+			path:           "/jobs/unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   model.CodeJobNotFound,
+		},
+		{
+			name:           "cancel unknown job",
+			method:         http.MethodDelete,
+			path:           "/jobs/unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   model.CodeJobNotFound,
+		},
+		{
+			name:           "job with wrong method",
+			method:         http.MethodPut,
+			path:           "/jobs/unknown",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedCode:   model.CodeMethodNotAllowed,
+		},
+	}
+
+	h := newJobHandler()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, _ := serveJob(t, h, tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, string(tt.expectedCode), decodeProblem(t, w).Code)
+		})
+	}
+}
+
+func TestJobHandlerOutlivesExecutionTimeout(t *testing.T) {
+	h := newJobHandlerWithScheduler(slowScheduler{delay: 50 * time.Millisecond, next: scheduler.NewSequentialScheduler()},
+		10*time.Millisecond, job_manager.DefaultLimit)
+
+	w, submitted := serveJob(t, h, http.MethodPost, "/jobs", "calc x = 10 + 2\ncalc y = x * 3\nprint x\nprint y")
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	job := awaitJob(t, h, submitted.ID)
+	assert.Equal(t, string(model.JobDone), job.Status)
+	require.Len(t, job.Items, 2)
+	for _, item := range job.Items {
+		assert.Equal(t, string(model.StatusOK), item.Status)
+	}
+	assert.Equal(t, float64(36), job.Items[1].Value)
+}
+
+func TestJobHandlerLimitsRunningJobs(t *testing.T) {
+	h := newJobHandlerWithScheduler(slowScheduler{delay: 50 * time.Millisecond, next: scheduler.NewSequentialScheduler()},
+		model.DefaultMaxTimeout, 1)
+
+	w, submitted := serveJob(t, h, http.MethodPost, "/jobs", "calc x = 10 + 2\nprint x")
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	w, _ = serveJob(t, h, http.MethodPost, "/jobs", "calc x = 10 + 2\nprint x")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, string(model.CodeTooManyJobs), decodeProblem(t, w).Code)
+
+	awaitJob(t, h, submitted.ID)
+
+	w, _ = serveJob(t, h, http.MethodPost, "/jobs", "calc x = 10 + 2\nprint x")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+}
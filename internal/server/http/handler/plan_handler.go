@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"industrial-calculator/internal/model"
+	"net/http"
+)
+
+type PlanHandler struct {
+	uc      planUsecase
+	builder programBuilder
+	parser  scriptParser
+}
+
+type planUsecase interface {
+	PlanInstructions(commands []model.Command) (*model.Plan, error)
+}
+
+func NewPlanHandler(usecase planUsecase, builder programBuilder, parser scriptParser) *PlanHandler {
+	return &PlanHandler{uc: usecase, builder: builder, parser: parser}
+}
+
+type PlanResponse struct {
+	Required           []PlannedCommand   `json:"required"`
+	Levels             [][]PlannedCommand `json:"levels"`
+	CriticalPathLength int                `json:"critical_path_length"`
+	CriticalPath       []PlannedCommand   `json:"critical_path"`
+	Dead               []PlannedCommand   `json:"dead"`
+}
+
+type PlannedCommand struct {
+	CommandIndex int    `json:"command_index"`
+	Var          string `json:"var"`
+}
+
+func (h *PlanHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	options, err := parseExecutionOptions(w, r)
+	if err != nil {
+		return
+	}
+
+	commands, err := decodeProgram(w, r, options, h.builder, h.parser)
+	if err != nil {
+		return
+	}
+
+	plan, err := h.uc.PlanInstructions(commands)
+	if err != nil {
+		writeExecutionError(err, w)
+		return
+	}
+
+	response := PlanResponse{
+		Required:           buildPlannedCommands(plan.Required),
+		Levels:             make([][]PlannedCommand, len(plan.Levels)),
+		CriticalPathLength: len(plan.CriticalPath),
+		CriticalPath:       buildPlannedCommands(plan.CriticalPath),
+		Dead:               buildPlannedCommands(plan.Dead),
+	}
+
+	for i, level := range plan.Levels {
+		response.Levels[i] = buildPlannedCommands(level)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func buildPlannedCommands(commands []model.PlannedCommand) []PlannedCommand {
+	result := make([]PlannedCommand, len(commands))
+	for i, command := range commands {
+		result[i] = PlannedCommand{CommandIndex: command.CommandIndex, Var: command.Var}
+	}
+
+	return result
+}
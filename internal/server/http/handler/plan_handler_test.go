@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_builder"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/script_parser"
+	"industrial-calculator/internal/server/http/handler"
+	"industrial-calculator/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPlanHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "levels, critical path and dead commands",
+			body: "calc a = 1 + 2\n" +
+				"calc b = a * 2\n" +
+				"calc c = 5 - 1\n" +
+				"calc d = b + c\n" +
+				"calc dead = d * 10\n" +
+				"print d",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"required":[
+					{"command_index":0,"var":"a"},{"command_index":1,"var":"b"},
+					{"command_index":2,"var":"c"},{"command_index":3,"var":"d"}
+				],
+				"levels":[
+					[{"command_index":0,"var":"a"},{"command_index":2,"var":"c"}],
+					[{"command_index":1,"var":"b"}],
+					[{"command_index":3,"var":"d"}]
+				],
+				"critical_path_length":3,
+				"critical_path":[
+					{"command_index":0,"var":"a"},{"command_index":1,"var":"b"},{"command_index":3,"var":"d"}
+				],
+				"dead":[{"command_index":4,"var":"dead"}]
+			}`,
+		},
+		{
+			name:           "nothing printed",
+			body:           "calc x = 1 + 1",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{
+				"required":[],"levels":[],"critical_path_length":0,"critical_path":[],
+				"dead":[{"command_index":0,"var":"x"}]
+			}`,
+		},
+		{
+			name:           "undefined variable",
+			body:           "calc x = y + 1\nprint x",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		program_validator.NewValidator(),
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		scheduler.NewSequentialScheduler(),
+		model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits,
+	)
+	h := handler.NewPlanHandler(uc, program_builder.NewBuilder(uc), script_parser.NewParser())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/plan", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "text/plain")
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			} else {
+				decodeProblem(t, w)
+			}
+		})
+	}
+}
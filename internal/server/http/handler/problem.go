@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"industrial-calculator/internal/model"
+	"net/http"
+)
+
+const problemContentType = "application/problem+json"
+
+type Problem struct {
+	Type   string         `json:"type"`
+	Title  string         `json:"title"`
+	Status int            `json:"status"`
+	Detail string         `json:"detail"`
+	Code   string         `json:"code"`
+	Errors []ProblemError `json:"errors,omitempty"`
+}
+
+type ProblemError struct {
+	Code         string `json:"code"`
+	Message      string `json:"message"`
+	CommandIndex *int   `json:"command_index,omitempty"`
+	Field        string `json:"field,omitempty"`
+	Var          string `json:"var,omitempty"`
+	Line         int    `json:"line,omitempty"`
+	Column       int    `json:"column,omitempty"`
+}
+
+func writeProblem(w http.ResponseWriter, status int, code model.ErrorCode, detail string,
+	details []model.ErrorDetail,
+) {
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   string(code),
+	}
+
+	for _, d := range details {
+		problemError := ProblemError{
+			Code:    string(d.Code),
+			Message: d.Message,
+			Field:   d.Field,
+			Var:     d.Var,
+			Line:    d.Line,
+			Column:  d.Column,
+		}
+
+		if d.CommandIndex != model.NoCommandIndex {
+			index := d.CommandIndex
+			problemError.CommandIndex = &index
+		}
+
+		problem.Errors = append(problem.Errors, problemError)
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+func writeExecutionError(err error, w http.ResponseWriter) {
+	if !errors.Is(err, model.ErrInvalidProgram) {
+		writeProblem(w, http.StatusInternalServerError, model.CodeInternal, "failed to execute instructions", nil)
+		return
+	}
+
+	details := model.ErrorDetails(err)
+
+	code := model.CodeInvalidProgram
+	if len(details) == 1 {
+		code = details[0].Code
+	}
+
+	writeProblem(w, http.StatusBadRequest, code, err.Error(), details)
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter) error {
+	err := errors.New("method not allowed")
+	writeProblem(w, http.StatusMethodNotAllowed, model.CodeMethodNotAllowed, err.Error(), nil)
+
+	return err
+}
+
+func writeInvalidRequestBody(w http.ResponseWriter) error {
+	err := errors.New("invalid request body")
+	writeProblem(w, http.StatusBadRequest, model.CodeInvalidRequest, err.Error(), nil)
+
+	return err
+}
+
+func writeProgramTooLarge(w http.ResponseWriter) error {
+	err := fmt.Errorf("program exceeds %d bytes", maxProgramSize)
+	writeProblem(w, http.StatusRequestEntityTooLarge, model.CodeProgramTooLarge, err.Error(), nil)
+
+	return err
+}
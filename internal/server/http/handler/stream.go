@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"industrial-calculator/internal/model"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	eventStreamContentType = "text/event-stream"
+	ndjsonContentType      = "application/x-ndjson"
+)
+
+type StreamItem struct {
+	Index int `json:"index"`
+	Item
+}
+
+type StreamSummary struct {
+	Status    string `json:"status"`
+	Total     int    `json:"total"`
+	OK        int    `json:"ok"`
+	Errors    int    `json:"errors"`
+	Timeouts  int    `json:"timeouts"`
+	Cancelled int    `json:"cancelled"`
+	ElapsedMs int64  `json:"elapsed_ms"`
+}
+
+type StreamEvent struct {
+	Item    *StreamItem    `json:"item,omitempty"`
+	Summary *StreamSummary `json:"summary,omitempty"`
+}
+
+type eventWriter func(w http.ResponseWriter, event StreamEvent) error
+
+func negotiateStream(r *http.Request) (string, eventWriter) {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case eventStreamContentType:
+			return eventStreamContentType, writeServerSentEvent
+		case ndjsonContentType:
+			return ndjsonContentType, writeJSONLine
+		}
+	}
+
+	return "", nil
+}
+
+func writeStream(w http.ResponseWriter, contentType string, write eventWriter, targets []*model.Variable,
+	resolved <-chan int, options model.ExecutionOptions, started time.Time,
+) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	controller := http.NewResponseController(w)
+	_ = controller.Flush()
+
+	summary := StreamSummary{Total: len(targets)}
+	for index := range resolved {
+		item := buildItem(targets[index], options)
+		countItem(&summary, item)
+
+		if err := write(w, StreamEvent{Item: &StreamItem{Index: index, Item: item}}); err != nil {
+			return
+		}
+		_ = controller.Flush()
+	}
+
+	summary.Status = overallStatus(summary)
+	summary.ElapsedMs = time.Since(started).Milliseconds()
+
+	if err := write(w, StreamEvent{Summary: &summary}); err != nil {
+		return
+	}
+	_ = controller.Flush()
+}
+
+func countItem(summary *StreamSummary, item Item) {
+	switch model.VariableStatus(item.Status) {
+	case model.StatusOK:
+		summary.OK++
+	case model.StatusError:
+		summary.Errors++
+	case model.StatusTimeout:
+		summary.Timeouts++
+	case model.StatusCancelled:
+		summary.Cancelled++
+	}
+}
+
+func overallStatus(summary StreamSummary) string {
+	switch {
+	case summary.Cancelled > 0:
+		return string(model.StatusCancelled)
+	case summary.Timeouts > 0:
+		return string(model.StatusTimeout)
+	case summary.Errors > 0:
+		return string(model.StatusError)
+	default:
+		return string(model.StatusOK)
+	}
+}
+
+func writeServerSentEvent(w http.ResponseWriter, event StreamEvent) error {
+	name, payload := "item", interface{}(event.Item)
+	if event.Summary != nil {
+		name, payload = "summary", event.Summary
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+
+	return err
+}
+
+func writeJSONLine(w http.ResponseWriter, event StreamEvent) error {
+	return json.NewEncoder(w).Encode(event)
+}
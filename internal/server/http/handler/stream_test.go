@@ -0,0 +1,161 @@
+package handler_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_builder"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/script_parser"
+	"industrial-calculator/internal/server/http/handler"
+	"industrial-calculator/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestServeHTTPStreaming(t *testing.T) {
+	tests := []struct {
+		name                string
+		accept              string
+		expectedContentType string
+		decode              func(t *testing.T, body string) []handler.StreamEvent
+	}{
+		{
+			name:                "server-sent events",
+			accept:              "text/event-stream",
+			expectedContentType: "text/event-stream",
+			decode:              decodeServerSentEvents,
+		},
+		{
+			name:                "ndjson",
+			accept:              "application/x-ndjson",
+			expectedContentType: "application/x-ndjson",
+			decode:              decodeJSONLines,
+		},
+		{
+			name:                "ndjson among other media types",
+			accept:              "application/json;q=0.5, application/x-ndjson",
+			expectedContentType: "application/x-ndjson",
+			decode:              decodeJSONLines,
+		},
+	}
+
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		program_validator.NewValidator(),
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		scheduler.NewPoolScheduler(4),
+		model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits,
+	)
+	h := handler.NewCalcExecutorHandler(uc, program_builder.NewBuilder(uc), script_parser.NewParser())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := "calc x = 10 + 2\ncalc y = x / 0\ncalc z = x * 3\nprint z\nprint y\nprint x"
+			req := httptest.NewRequest(http.MethodPost, "/process", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "text/plain")
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+
+			events := tt.decode(t, w.Body.String())
+			require.Len(t, events, 4)
+
+			summary := events[len(events)-1].Summary
+			require.NotNil(t, summary)
+			assert.Equal(t, "error", summary.Status)
+			assert.Equal(t, 3, summary.Total)
+			assert.Equal(t, 2, summary.OK)
+			assert.Equal(t, 1, summary.Errors)
+
+			items := make([]*handler.StreamItem, 0, 3)
+			for _, event := range events[:len(events)-1] {
+				require.NotNil(t, event.Item)
+				items = append(items, event.Item)
+			}
+
+			sort.Slice(items, func(i, j int) bool { return items[i].Index < items[j].Index })
+			assert.Equal(t, "z", items[0].Var)
+			assert.Equal(t, float64(36), items[0].Value)
+			assert.Equal(t, "error", items[1].Status)
+			assert.Equal(t, float64(12), items[2].Value)
+		})
+	}
+}
+
+func TestServeHTTPStreamingRejectsInvalidProgram(t *testing.T) {
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		program_validator.NewValidator(),
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		scheduler.NewSequentialScheduler(),
+		model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits,
+	)
+	h := handler.NewCalcExecutorHandler(uc, program_builder.NewBuilder(uc), script_parser.NewParser())
+
+	req := httptest.NewRequest(http.MethodPost, "/process", bytes.NewBufferString("print x"))
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Accept", "text/event-stream")
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	decodeProblem(t, w)
+}
+
+func decodeServerSentEvents(t *testing.T, body string) []handler.StreamEvent {
+	var events []handler.StreamEvent
+	for _, block := range strings.Split(strings.TrimSpace(body), "\n\n") {
+		lines := strings.Split(block, "\n")
+		require.Len(t, lines, 2)
+
+		name := strings.TrimPrefix(lines[0], "event: ")
+		data := []byte(strings.TrimPrefix(lines[1], "data: "))
+
+		var event handler.StreamEvent
+		switch name {
+		case "item":
+			event.Item = &handler.StreamItem{}
+			require.NoError(t, json.Unmarshal(data, event.Item))
+		case "summary":
+			event.Summary = &handler.StreamSummary{}
+			require.NoError(t, json.Unmarshal(data, event.Summary))
+		default:
+			t.Fatalf("unexpected event %q", name)
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func decodeJSONLines(t *testing.T, body string) []handler.StreamEvent {
+	var events []handler.StreamEvent
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		var event handler.StreamEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+
+	return events
+}
@@ -2,9 +2,13 @@ package http
 
 import "net/http"
 
-func StartHTTPServer(handler http.Handler) {
+type Routes map[string]http.Handler
+
+func StartHTTPServer(routes Routes) {
 	mux := http.NewServeMux()
-	mux.Handle("/process", handler)
+	for pattern, handler := range routes {
+		mux.Handle(pattern, handler)
+	}
 
 	server := &http.Server{
 		Addr:    ":8080",
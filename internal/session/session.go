@@ -0,0 +1,276 @@
+package session
+
+import (
+	"context"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/sentence"
+	"sync"
+	"time"
+)
+
+type Manager struct {
+	policy       assignment_resolver.Policy
+	defaultMode  model.ExecutionMode
+	workers      int
+	maxTimeout   time.Duration
+	maxValueBits int
+}
+
+func NewManager(policy assignment_resolver.Policy, defaultMode model.ExecutionMode, workers int,
+	maxTimeout time.Duration, maxValueBits int,
+) *Manager {
+	return &Manager{
+		policy:       policy,
+		defaultMode:  defaultMode,
+		workers:      workers,
+		maxTimeout:   maxTimeout,
+		maxValueBits: maxValueBits,
+	}
+}
+
+// Start opens a session whose calcs share one deadline and a fixed set of
+// workers; a calc is queued only once all its dependencies are resolved.
+func (m *Manager) Start(ctx context.Context, options model.ExecutionOptions) *Session {
+	timeout := m.maxTimeout
+	if options.Timeout > 0 && options.Timeout < timeout {
+		timeout = options.Timeout
+	}
+
+	mode := options.Mode
+	if mode == "" {
+		mode = m.defaultMode
+	}
+
+	workers := m.workers
+	if mode == model.ModeSequential {
+		workers = 1
+	}
+
+	options.MaxValueBits = m.maxValueBits
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	s := &Session{
+		ctx:          ctx,
+		cancel:       cancel,
+		options:      options,
+		versioned:    m.policy == assignment_resolver.Versioned,
+		assignments:  make(map[*model.Variable]int),
+		dependencies: make(map[*model.Variable][]*model.Variable),
+		references:   make(map[*model.Variable]int),
+		tasks:        make(map[*model.Variable]task),
+		pending:      make(map[*model.Variable]int),
+		dependents:   make(map[*model.Variable][]*model.Variable),
+	}
+	s.wake = sync.NewCond(&s.mu)
+
+	s.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go s.work()
+	}
+
+	go s.expire()
+
+	return s
+}
+
+type task struct {
+	index   int
+	command model.Command
+}
+
+type Session struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	options      model.ExecutionOptions
+	versioned    bool
+	assignments  map[*model.Variable]int
+	dependencies map[*model.Variable][]*model.Variable
+	references   map[*model.Variable]int
+
+	mu         sync.Mutex
+	wake       *sync.Cond
+	tasks      map[*model.Variable]task
+	pending    map[*model.Variable]int
+	dependents map[*model.Variable][]*model.Variable
+	ready      []*model.Variable
+	running    int
+	closed     bool
+	workers    sync.WaitGroup
+}
+
+// Submit returns the command as it will be calculated: under the versioned
+// policy a reassignment gets a new variable that later commands must refer to.
+func (s *Session) Submit(index int, command model.Command) (model.Command, error) {
+	if command.IsPrint() {
+		s.reference(index, command.Var)
+		return command, nil
+	}
+
+	if first, ok := s.assignments[command.Var]; ok {
+		if !s.versioned {
+			return command, &model.ReassignmentError{
+				Var:               command.Var.GetName(),
+				FirstCommandIndex: first,
+				CommandIndex:      index,
+			}
+		}
+
+		command.Var = model.NewVariable(command.Var.GetName())
+	}
+
+	var dependencies []*model.Variable
+	for _, argument := range []model.Argument{command.Left, command.Right} {
+		if dependency, ok := argument.(*model.Variable); ok {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	for _, dependency := range dependencies {
+		if path := s.pathTo(dependency, command.Var, make(map[*model.Variable]struct{})); path != nil {
+			names := []string{command.Var.GetName()}
+			for _, variable := range path {
+				names = append(names, variable.GetName())
+			}
+
+			return command, &model.CycleError{Path: names}
+		}
+	}
+
+	for _, dependency := range dependencies {
+		s.reference(index, dependency)
+	}
+
+	s.assignments[command.Var] = index
+	s.dependencies[command.Var] = dependencies
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tasks[command.Var] = task{index: index, command: command}
+	s.running++
+	for _, dependency := range dependencies {
+		if !dependency.IsResolved() && s.ctx.Err() == nil {
+			s.pending[command.Var]++
+			s.dependents[dependency] = append(s.dependents[dependency], command.Var)
+		}
+	}
+
+	if s.pending[command.Var] == 0 {
+		s.enqueue(command.Var)
+	}
+
+	return command, nil
+}
+
+func (s *Session) Close() {
+	s.mu.Lock()
+	for variable, index := range s.references {
+		if _, ok := s.assignments[variable]; !ok {
+			variable.SetError(&model.UndefinedVariableError{Var: variable.GetName(), CommandIndex: index})
+			s.release(variable)
+		}
+	}
+
+	s.closed = true
+	s.wake.Broadcast()
+	s.mu.Unlock()
+
+	s.workers.Wait()
+	s.cancel()
+}
+
+func (s *Session) work() {
+	defer s.workers.Done()
+
+	for {
+		s.mu.Lock()
+		for len(s.ready) == 0 && !(s.closed && s.running == 0) {
+			s.wake.Wait()
+		}
+
+		if len(s.ready) == 0 {
+			s.mu.Unlock()
+			return
+		}
+
+		variable := s.ready[0]
+		s.ready = s.ready[1:]
+		next := s.tasks[variable]
+		delete(s.tasks, variable)
+		s.mu.Unlock()
+
+		calc := sentence.NewSentence(next.index, next.command, s.options)
+		if err := s.ctx.Err(); err != nil {
+			calc.Cancel(err)
+		} else {
+			calc.Evaluate()
+		}
+
+		s.mu.Lock()
+		s.running--
+		s.release(variable)
+		if s.running == 0 {
+			s.wake.Broadcast()
+		}
+		s.mu.Unlock()
+	}
+}
+
+// expire hands calcs still waiting for their dependencies to the workers,
+// which cancel them once the session deadline has passed.
+func (s *Session) expire() {
+	<-s.ctx.Done()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for variable := range s.pending {
+		s.enqueue(variable)
+	}
+
+	clear(s.dependents)
+}
+
+func (s *Session) release(variable *model.Variable) {
+	for _, dependent := range s.dependents[variable] {
+		s.pending[dependent]--
+		if s.pending[dependent] == 0 {
+			s.enqueue(dependent)
+		}
+	}
+
+	delete(s.dependents, variable)
+}
+
+func (s *Session) enqueue(variable *model.Variable) {
+	delete(s.pending, variable)
+	s.ready = append(s.ready, variable)
+	s.wake.Signal()
+}
+
+func (s *Session) reference(index int, variable *model.Variable) {
+	if _, ok := s.references[variable]; !ok {
+		s.references[variable] = index
+	}
+}
+
+func (s *Session) pathTo(from, to *model.Variable, visited map[*model.Variable]struct{}) []*model.Variable {
+	if from == to {
+		return []*model.Variable{from}
+	}
+
+	if _, ok := visited[from]; ok {
+		return nil
+	}
+
+	visited[from] = struct{}{}
+
+	for _, dependency := range s.dependencies[from] {
+		if path := s.pathTo(dependency, to, visited); path != nil {
+			return append([]*model.Variable{from}, path...)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_builder"
+	"industrial-calculator/internal/session"
+	"testing"
+	"time"
+)
+
+func newSubmitter(t *testing.T, s *session.Session, options model.ExecutionOptions,
+) func(index int, instruction model.Instruction) (model.Command, error) {
+	builder := program_builder.NewBuilder(noPlanner{})
+	vars := make(map[string]*model.Variable)
+
+	return func(index int, instruction model.Instruction) (model.Command, error) {
+		command, err := builder.BuildCommand(index, instruction, vars, options)
+		require.NoError(t, err)
+
+		command, err = s.Submit(index, command)
+		if err == nil && command.IsCalc() {
+			vars[command.Var.GetName()] = command.Var
+		}
+
+		return command, err
+	}
+}
+
+func TestSession(t *testing.T) {
+	options := model.DefaultExecutionOptions()
+	s := session.NewManager(assignment_resolver.Reject, model.ModeConcurrent, 4, model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits).Start(context.Background(), options)
+	submit := newSubmitter(t, s, options)
+
+	y, err := submit(0, model.Instruction{Type: model.Calc, Var: "y", Op: "*",
+		Left: model.NameOperand("x"), Right: model.NumberOperand("2")})
+	require.NoError(t, err)
+	assert.False(t, y.Var.IsResolved())
+
+	x, err := submit(1, model.Instruction{Type: model.Calc, Var: "x", Op: "+",
+		Left: model.NumberOperand("1"), Right: model.NumberOperand("2")})
+	require.NoError(t, err)
+
+	<-y.Var.Done()
+	value, err := y.Var.GetValue()
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), value)
+
+	_, err = submit(2, model.Instruction{Type: model.Calc, Var: "x", Op: "+",
+		Left: model.NumberOperand("1"), Right: model.NumberOperand("1")})
+	var reassignment *model.ReassignmentError
+	require.True(t, errors.As(err, &reassignment))
+	assert.Equal(t, 1, reassignment.FirstCommandIndex)
+
+	_, err = submit(3, model.Instruction{Type: model.Calc, Var: "a", Op: "+",
+		Left: model.NameOperand("b"), Right: model.NameOperand("x")})
+	require.NoError(t, err)
+
+	_, err = submit(4, model.Instruction{Type: model.Calc, Var: "b", Op: "+",
+		Left: model.NameOperand("a"), Right: model.NumberOperand("1")})
+	var cycle *model.CycleError
+	require.True(t, errors.As(err, &cycle))
+	assert.Equal(t, []string{"b", "a", "b"}, cycle.Path)
+
+	missing, err := submit(5, model.Instruction{Type: model.Print, Var: "missing"})
+	require.NoError(t, err)
+
+	s.Close()
+	assert.Equal(t, model.StatusOK, x.Var.Status())
+
+	_, err = missing.Var.GetValue()
+	var undefined *model.UndefinedVariableError
+	require.True(t, errors.As(err, &undefined))
+	assert.Equal(t, 5, undefined.CommandIndex)
+}
+
+func TestSessionVersionsReassignments(t *testing.T) {
+	options := model.DefaultExecutionOptions()
+	s := session.NewManager(assignment_resolver.Versioned, model.ModeSequential, 4, model.DefaultMaxTimeout,
+		model.DefaultMaxValueBits).Start(context.Background(), options)
+	submit := newSubmitter(t, s, options)
+
+	first, err := submit(0, model.Instruction{Type: model.Calc, Var: "x", Op: "+",
+		Left: model.NumberOperand("1"), Right: model.NumberOperand("2")})
+	require.NoError(t, err)
+
+	second, err := submit(1, model.Instruction{Type: model.Calc, Var: "x", Op: "*",
+		Left: model.NameOperand("x"), Right: model.NumberOperand("10")})
+	require.NoError(t, err)
+	assert.NotSame(t, first.Var, second.Var)
+
+	printed, err := submit(2, model.Instruction{Type: model.Print, Var: "x"})
+	require.NoError(t, err)
+	assert.Same(t, second.Var, printed.Var)
+
+	s.Close()
+
+	value, err := printed.Var.GetValue()
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), value)
+}
+
+func TestSessionDeadline(t *testing.T) {
+	options := model.DefaultExecutionOptions()
+	options.Timeout = time.Hour
+	s := session.NewManager(assignment_resolver.Reject, model.ModeConcurrent, 4, 10*time.Millisecond,
+		model.DefaultMaxValueBits).Start(context.Background(), options)
+	submit := newSubmitter(t, s, options)
+
+	waiting, err := submit(0, model.Instruction{Type: model.Calc, Var: "y", Op: "+",
+		Left: model.NameOperand("x"), Right: model.NumberOperand("1")})
+	require.NoError(t, err)
+
+	select {
+	case <-waiting.Var.Done():
+	case <-time.After(time.Second):
+		require.Fail(t, "calc waiting for a dependency must be cancelled at the deadline")
+	}
+	assert.Equal(t, model.StatusTimeout, waiting.Var.Status())
+
+	late, err := submit(1, model.Instruction{Type: model.Calc, Var: "x", Op: "+",
+		Left: model.NumberOperand("1"), Right: model.NumberOperand("2")})
+	require.NoError(t, err)
+
+	s.Close()
+	assert.Equal(t, model.StatusTimeout, late.Var.Status())
+}
+
+type noPlanner struct{}
+
+func (noPlanner) PlanInstructions(commands []model.Command) (*model.Plan, error) {
+	return &model.Plan{}, nil
+}
@@ -2,25 +2,176 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"industrial-calculator/internal/model"
-	"industrial-calculator/internal/sentence"
-	"sync"
+	"industrial-calculator/internal/scheduler"
+	"strconv"
+	"time"
 )
 
 type requiredVariablesFinder interface {
-	FindRequiredVariables(calcCommandByVariable map[*model.Variable]model.Command, targets []*model.Variable) map[*model.Variable]struct{}
+	FindRequiredVariables(calcCommandByVariable map[*model.Variable]model.Command, targets []*model.Variable) (map[*model.Variable]struct{}, error)
+}
+
+type programValidator interface {
+	Validate(commands []model.Command) error
+}
+
+type assignmentResolver interface {
+	Resolve(commands []model.Command) ([]model.Command, error)
+}
+
+type calcScheduler interface {
+	Run(ctx context.Context, tasks map[*model.Variable]scheduler.Task, options model.ExecutionOptions)
 }
 
 type CalcExecutorUsecase struct {
-	finder requiredVariablesFinder
+	finder       requiredVariablesFinder
+	validator    programValidator
+	resolver     assignmentResolver
+	scheduler    calcScheduler
+	maxTimeout   time.Duration
+	maxValueBits int
+}
+
+func NewCalcExectureUsecase(finder requiredVariablesFinder, validator programValidator, resolver assignmentResolver,
+	scheduler calcScheduler, maxTimeout time.Duration, maxValueBits int,
+) *CalcExecutorUsecase {
+	return &CalcExecutorUsecase{
+		finder:       finder,
+		validator:    validator,
+		resolver:     resolver,
+		scheduler:    scheduler,
+		maxTimeout:   maxTimeout,
+		maxValueBits: maxValueBits,
+	}
+}
+
+func ParseMaxTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return model.DefaultMaxTimeout, nil
+	}
+
+	timeout, err := model.ParseTimeout(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid execution timeout: %q", value)
+	}
+
+	return timeout, nil
+}
+
+func ParseMaxValueBits(value string) (int, error) {
+	if value == "" {
+		return model.DefaultMaxValueBits, nil
+	}
+
+	bits, err := strconv.Atoi(value)
+	if err != nil || bits <= 0 {
+		return 0, fmt.Errorf("invalid max value bits: %q", value)
+	}
+
+	return bits, nil
+}
+
+func (c *CalcExecutorUsecase) ExecuteInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+) ([]*model.Variable, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout(options))
+	defer cancel()
+
+	printTargets, _, err := c.execute(ctx, commands, options)
+
+	return printTargets, err
+}
+
+func (c *CalcExecutorUsecase) ExplainInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+) ([]*model.Derivation, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout(options))
+	defer cancel()
+
+	printTargets, tasks, err := c.execute(ctx, commands, options)
+	if err != nil {
+		return nil, err
+	}
+
+	derivations := make([]*model.Derivation, len(printTargets))
+	for i, target := range printTargets {
+		derivations[i] = explain(target, tasks, make(map[*model.Variable]struct{}))
+	}
+
+	return derivations, nil
+}
+
+func (c *CalcExecutorUsecase) PlanInstructions(commands []model.Command) (*model.Plan, error) {
+	commands, _, tasks, err := c.prepare(commands)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan(commands, tasks), nil
+}
+
+func (c *CalcExecutorUsecase) StartInstructions(ctx context.Context, commands []model.Command,
+	options model.ExecutionOptions,
+) ([]*model.Variable, <-chan int, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout(options))
+
+	_, printTargets, tasks, err := c.prepare(commands)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	if options.Progress != nil {
+		options.Progress.Track(len(tasks))
+	}
+
+	feed := model.NewResolutionFeed(printTargets)
+	options.Resolved = feed.Notify
+
+	go func() {
+		defer cancel()
+		defer feed.Close()
+		c.run(ctx, tasks, options)
+	}()
+
+	return printTargets, feed.Indexes(), nil
 }
 
-func NewCalcExectureUsecase(finder requiredVariablesFinder) *CalcExecutorUsecase {
-	return &CalcExecutorUsecase{finder: finder}
+func (c *CalcExecutorUsecase) execute(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+) ([]*model.Variable, map[*model.Variable]scheduler.Task, error) {
+	_, printTargets, tasks, err := c.prepare(commands)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if options.Progress != nil {
+		options.Progress.Track(len(tasks))
+	}
+
+	c.run(ctx, tasks, options)
+
+	return printTargets, tasks, nil
+}
+
+func (c *CalcExecutorUsecase) run(ctx context.Context, tasks map[*model.Variable]scheduler.Task,
+	options model.ExecutionOptions,
+) {
+	options.MaxValueBits = c.maxValueBits
+	c.scheduler.Run(ctx, tasks, options)
 }
 
-func (c *CalcExecutorUsecase) ExecuteInstructions(ctx context.Context, commands []model.Command) []*model.Variable {
+func (c *CalcExecutorUsecase) prepare(commands []model.Command,
+) ([]model.Command, []*model.Variable, map[*model.Variable]scheduler.Task, error) {
+	resolved, resolveErr := c.resolver.Resolve(commands)
+	if resolveErr == nil {
+		commands = resolved
+	}
+
+	validateErr := c.validator.Validate(commands)
+
 	calcCommandsByVariable := make(map[*model.Variable]model.Command)
+	tasks := make(map[*model.Variable]scheduler.Task)
 	printTargets := make([]*model.Variable, 0)
 
 	for i := range commands {
@@ -28,25 +179,33 @@ func (c *CalcExecutorUsecase) ExecuteInstructions(ctx context.Context, commands
 			printTargets = append(printTargets, commands[i].Var)
 		} else if commands[i].IsCalc() {
 			calcCommandsByVariable[commands[i].Var] = commands[i]
+			tasks[commands[i].Var] = scheduler.Task{Index: i, Command: commands[i]}
 		}
 	}
 
-	requiredVariables := c.finder.FindRequiredVariables(calcCommandsByVariable, printTargets)
+	requiredVariables, findErr := c.finder.FindRequiredVariables(calcCommandsByVariable, printTargets)
+	if err := errors.Join(resolveErr, validateErr, findErr); err != nil {
+		return nil, nil, nil, err
+	}
 
-	var wg sync.WaitGroup
-	for variable := range requiredVariables {
-		cmd := calcCommandsByVariable[variable]
+	for variable := range tasks {
+		if _, ok := requiredVariables[variable]; !ok {
+			delete(tasks, variable)
+		}
+	}
 
-		sentence := sentence.NewSentence(variable, cmd.Op, cmd.Left, cmd.Right)
+	return commands, printTargets, tasks, nil
+}
 
-		wg.Add(1)
-		go func() {
-			sentence.Calc(ctx)
-			wg.Done()
-		}()
+func (c *CalcExecutorUsecase) timeout(options model.ExecutionOptions) time.Duration {
+	maxTimeout := c.maxTimeout
+	if options.MaxTimeout > 0 {
+		maxTimeout = options.MaxTimeout
 	}
 
-	wg.Wait()
+	if options.Timeout > 0 && options.Timeout < maxTimeout {
+		return options.Timeout
+	}
 
-	return printTargets
+	return maxTimeout
 }
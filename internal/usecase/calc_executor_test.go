@@ -0,0 +1,52 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"industrial-calculator/internal/assignment_resolver"
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/program_validator"
+	"industrial-calculator/internal/required_variables_finder"
+	"industrial-calculator/internal/scheduler"
+	"industrial-calculator/internal/usecase"
+	"testing"
+	"time"
+)
+
+type slowValidator struct {
+	delay time.Duration
+}
+
+func (v slowValidator) Validate(commands []model.Command) error {
+	time.Sleep(v.delay)
+	return program_validator.NewValidator().Validate(commands)
+}
+
+func TestClientTimeoutIsCappedByServerMax(t *testing.T) {
+	x := model.NewVariable("x")
+	commands := []model.Command{
+		{Type: model.Calc, Var: x, Op: model.Plus, Left: model.NumericArgument(1), Right: model.NumericArgument(2)},
+		{Type: model.Print, Var: x},
+	}
+
+	uc := usecase.NewCalcExectureUsecase(
+		required_variables_finder.NewFinder(),
+		slowValidator{delay: 50 * time.Millisecond},
+		assignment_resolver.NewResolver(assignment_resolver.Reject),
+		scheduler.NewSequentialScheduler(),
+		10*time.Millisecond,
+		model.DefaultMaxValueBits,
+	)
+
+	options := model.DefaultExecutionOptions()
+	options.Timeout = time.Hour
+
+	started := time.Now()
+	result, err := uc.ExecuteInstructions(context.Background(), commands, options)
+	require.NoError(t, err)
+
+	require.Len(t, result, 1)
+	assert.Equal(t, model.StatusTimeout, result[0].Status())
+	assert.Less(t, time.Since(started), time.Second)
+}
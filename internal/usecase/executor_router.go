@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"industrial-calculator/internal/model"
+)
+
+type calcExecutor interface {
+	ExecuteInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+	) ([]*model.Variable, error)
+	ExplainInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+	) ([]*model.Derivation, error)
+	PlanInstructions(commands []model.Command) (*model.Plan, error)
+	StartInstructions(ctx context.Context, commands []model.Command, options model.ExecutionOptions,
+	) ([]*model.Variable, <-chan int, error)
+}
+
+type ExecutorRouter struct {
+	executors   map[model.ExecutionMode]calcExecutor
+	defaultMode model.ExecutionMode
+}
+
+func NewExecutorRouter(defaultMode model.ExecutionMode, concurrent, sequential calcExecutor) *ExecutorRouter {
+	return &ExecutorRouter{
+		executors: map[model.ExecutionMode]calcExecutor{
+			model.ModeConcurrent: concurrent,
+			model.ModeSequential: sequential,
+		},
+		defaultMode: defaultMode,
+	}
+}
+
+func ParseExecutionMode(value string) (model.ExecutionMode, error) {
+	if value == "" {
+		return model.ModeConcurrent, nil
+	}
+
+	mode := model.ExecutionMode(value)
+	if !model.IsValidExecutionMode(mode) {
+		return "", fmt.Errorf("invalid execution mode: %q", value)
+	}
+
+	return mode, nil
+}
+
+func (r *ExecutorRouter) ExecuteInstructions(ctx context.Context, commands []model.Command,
+	options model.ExecutionOptions,
+) ([]*model.Variable, error) {
+	executor, err := r.executor(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return executor.ExecuteInstructions(ctx, commands, options)
+}
+
+func (r *ExecutorRouter) ExplainInstructions(ctx context.Context, commands []model.Command,
+	options model.ExecutionOptions,
+) ([]*model.Derivation, error) {
+	executor, err := r.executor(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return executor.ExplainInstructions(ctx, commands, options)
+}
+
+func (r *ExecutorRouter) StartInstructions(ctx context.Context, commands []model.Command,
+	options model.ExecutionOptions,
+) ([]*model.Variable, <-chan int, error) {
+	executor, err := r.executor(options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return executor.StartInstructions(ctx, commands, options)
+}
+
+func (r *ExecutorRouter) PlanInstructions(commands []model.Command) (*model.Plan, error) {
+	return r.executors[r.defaultMode].PlanInstructions(commands)
+}
+
+func (r *ExecutorRouter) executor(options model.ExecutionOptions) (calcExecutor, error) {
+	mode := options.Mode
+	if mode == "" {
+		mode = r.defaultMode
+	}
+
+	executor, ok := r.executors[mode]
+	if !ok {
+		return nil, &model.OptionError{Option: "mode", Value: string(mode)}
+	}
+
+	return executor, nil
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/scheduler"
+)
+
+func explain(variable *model.Variable, tasks map[*model.Variable]scheduler.Task, expanded map[*model.Variable]struct{},
+) *model.Derivation {
+	task := tasks[variable]
+	derivation := &model.Derivation{
+		Variable:     variable,
+		CommandIndex: task.Index,
+		Op:           task.Command.Op,
+		Rounding:     task.Command.Rounding,
+	}
+
+	if _, ok := expanded[variable]; ok {
+		derivation.Shared = true
+		return derivation
+	}
+
+	expanded[variable] = struct{}{}
+	derivation.Left = explainArgument(task.Command.Left, tasks, expanded)
+	derivation.Right = explainArgument(task.Command.Right, tasks, expanded)
+
+	return derivation
+}
+
+func explainArgument(argument model.Argument, tasks map[*model.Variable]scheduler.Task,
+	expanded map[*model.Variable]struct{},
+) *model.Derivation {
+	if variable, ok := argument.(*model.Variable); ok {
+		return explain(variable, tasks, expanded)
+	}
+
+	return &model.Derivation{Literal: argument, CommandIndex: model.NoCommandIndex}
+}
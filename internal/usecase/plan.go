@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"industrial-calculator/internal/model"
+	"industrial-calculator/internal/scheduler"
+	"slices"
+)
+
+func plan(commands []model.Command, tasks map[*model.Variable]scheduler.Task) *model.Plan {
+	result := &model.Plan{Commands: commands}
+	levels := make(map[*model.Variable]int, len(tasks))
+	previous := make(map[*model.Variable]*model.Variable, len(tasks))
+
+	var level func(variable *model.Variable) int
+	level = func(variable *model.Variable) int {
+		if value, ok := levels[variable]; ok {
+			return value
+		}
+
+		value := 0
+		task := tasks[variable]
+		for _, argument := range []model.Argument{task.Command.Left, task.Command.Right} {
+			dependency, ok := argument.(*model.Variable)
+			if !ok {
+				continue
+			}
+
+			if _, ok := tasks[dependency]; !ok {
+				continue
+			}
+
+			if dependencyLevel := level(dependency) + 1; dependencyLevel > value {
+				value = dependencyLevel
+				previous[variable] = dependency
+			}
+		}
+
+		levels[variable] = value
+		return value
+	}
+
+	var last *model.Variable
+	for i, cmd := range commands {
+		if !cmd.IsCalc() {
+			continue
+		}
+
+		planned := model.PlannedCommand{CommandIndex: i, Var: cmd.Var.GetName()}
+		if task, ok := tasks[cmd.Var]; !ok || task.Index != i {
+			result.Dead = append(result.Dead, planned)
+			continue
+		}
+
+		result.Required = append(result.Required, planned)
+
+		value := level(cmd.Var)
+		for len(result.Levels) <= value {
+			result.Levels = append(result.Levels, nil)
+		}
+		result.Levels[value] = append(result.Levels[value], planned)
+
+		if last == nil || value > levels[last] {
+			last = cmd.Var
+		}
+	}
+
+	for variable := last; variable != nil; variable = previous[variable] {
+		planned := model.PlannedCommand{CommandIndex: tasks[variable].Index, Var: variable.GetName()}
+		result.CriticalPath = append(result.CriticalPath, planned)
+	}
+
+	slices.Reverse(result.CriticalPath)
+
+	return result
+}